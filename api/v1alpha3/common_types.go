@@ -38,6 +38,12 @@ const (
 	// on the reconciled object.
 	PausedAnnotation = "cluster.x-k8s.io/paused"
 
+	// ManagedByAnnotation is an annotation that can be applied to infrastructure objects, e.g. InfraCluster resources, to
+	// signify that some external system is managing the infrastructure.
+	//
+	// Infrastructure provider controllers ignore objects with this annotation, and clusterctl move can leave them in place.
+	ManagedByAnnotation = "cluster.x-k8s.io/managed-by"
+
 	// ClusterSecretType defines the type of secret created by core components
 	ClusterSecretType corev1.SecretType = "cluster.x-k8s.io/secret" //nolint:gosec
 )
@@ -25,6 +25,18 @@ const (
 	// ClusterctlCoreLabelName is applied to all the core objects managed by clusterctl.
 	ClusterctlCoreLabelName = "clusterctl.cluster.x-k8s.io/core"
 
+	// ClusterctlMoveSharedLabelName is applied to namespaced objects shared by many Clusters, e.g. credential Secrets,
+	// that should be moved together with the cluster-scoped objects when running clusterctl move in shared-only mode.
+	ClusterctlMoveSharedLabelName = "clusterctl.cluster.x-k8s.io/move-shared"
+
+	// ClusterctlMovedFromAnnotation is applied by clusterctl move, if required, to the objects created in the target management
+	// cluster, recording the kubeconfig context of the source management cluster or the directory the objects were restored from.
+	ClusterctlMovedFromAnnotation = "clusterctl.cluster.x-k8s.io/moved-from"
+
+	// ClusterctlMovedAtAnnotation is applied by clusterctl move, if required, to the objects created in the target management
+	// cluster, recording when the move started in the RFC3339 format.
+	ClusterctlMovedAtAnnotation = "clusterctl.cluster.x-k8s.io/moved-at"
+
 	// ClusterctlResourceLifecyleLabelName describes the lifecyle for a specific resource.
 	//
 	// Example: resources shared between instances of the same provider:  CRDs,
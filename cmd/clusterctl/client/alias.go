@@ -36,3 +36,32 @@ type Template repository.Template
 
 // Template wraps a YAML file that defines the cluster objects (Cluster, Machines etc.).
 type UpgradePlan cluster.UpgradePlan
+
+// MoveReport reports the outcome of a move operation, as returned by MoveWithReport.
+type MoveReport cluster.MoveSummary
+
+// MoveGraph is a read-only snapshot of the graph of the objects discovered by move.
+type MoveGraph cluster.MoveGraph
+
+// MoveGraphObject is an object in a MoveGraph, together with its dependencies.
+type MoveGraphObject = cluster.MoveGraphObject
+
+// ObjectTransformer changes an object before it is created in the target management cluster by move.
+type ObjectTransformer cluster.ObjectTransformer
+
+// Errors returned by Move, which automation can match using errors.Is.
+var (
+	ErrMissingProviders = cluster.ErrMissingProviders
+	ErrDependencyCycle  = cluster.ErrDependencyCycle
+	ErrTargetNotReady   = cluster.ErrTargetNotReady
+)
+
+// MissingProvidersError reports the providers missing in the target management cluster, or older than in the source.
+// Nb. Error types are type aliases instead of defined types, so errors.As matches the errors returned by the low-level library.
+type MissingProvidersError = cluster.MissingProvidersError
+
+// DependencyCycleError reports the objects in a cycle of owner references.
+type DependencyCycleError = cluster.DependencyCycleError
+
+// TargetNotReadyError reports the provider Deployments not available in the target management cluster.
+type TargetNotReadyError = cluster.TargetNotReadyError
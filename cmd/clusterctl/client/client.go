@@ -17,6 +17,8 @@ limitations under the License.
 package client
 
 import (
+	"time"
+
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
@@ -61,12 +63,370 @@ type MoveOptions struct {
 	// default rules for kubeconfig discovery will be used.
 	FromKubeconfig string
 
+	// FromKubeconfigContext defines the context within FromKubeconfig to use for accessing the source management cluster.
+	// If empty, the current context will be used.
+	FromKubeconfigContext string
+
+	// FromDirectory defines the path to a directory where objects were previously saved using ToDirectory; when set,
+	// objects are restored from the directory to the target management cluster instead of being moved from a
+	// source management cluster. FromKubeconfig and FromDirectory are mutually exclusive.
+	FromDirectory string
+
+	// FromArchive defines the path to a gzip-compressed tarball where objects were previously saved using ToArchive; it
+	// is handled the same way FromDirectory is. FromDirectory and FromArchive are mutually exclusive.
+	FromArchive string
+
 	// ToKubeconfig defines the path to the kubeconfig file to use for accessing the target management cluster.
 	ToKubeconfig string
 
-	// Namespace where the objects describing the workload cluster exists. If unspecified, the current
-	// namespace will be used.
+	// ToKubeconfigContext defines the context within ToKubeconfig to use for accessing the target management cluster.
+	// If empty, the current context will be used.
+	ToKubeconfigContext string
+
+	// ToKubeconfigSecret defines, in the namespace/name format, a Secret in the source management cluster storing the
+	// kubeconfig to use for accessing the target management cluster under the value key, following the Cluster API
+	// convention for the kubeconfig of the workload clusters; it can't be set together with ToKubeconfig.
+	ToKubeconfigSecret string
+
+	// ToDirectory defines the path to a directory where the objects should be saved, one YAML file for each object,
+	// instead of moving them to a target management cluster. ToKubeconfig and ToDirectory are mutually exclusive.
+	ToDirectory string
+
+	// ToArchive defines the path to a gzip-compressed tarball where the objects should be saved, together with the manifest
+	// listing their checksum, instead of saving them to a directory; objects are streamed into the archive one at a time.
+	// ToDirectory and ToArchive are mutually exclusive.
+	ToArchive string
+
+	// Namespace where the objects describing the workload cluster exists. If unspecified, the namespace of the current
+	// context of the source kubeconfig will be used; if the context does not define a namespace, the default namespace is used.
 	Namespace string
+
+	// Namespaces lists the namespaces where the objects describing the workload clusters exist, for moving related clusters
+	// hosted in many namespaces in a single operation, so references between objects in different namespaces are preserved.
+	// Namespaces can't be used together with Namespace or ToNamespace.
+	Namespaces []string
+
+	// AllNamespaces means the objects are moved from all the namespaces, making this intent explicit.
+	// AllNamespaces can't be used together with Namespace, Namespaces or ToNamespace.
+	AllNamespaces bool
+
+	// FromContextNamespace means the objects are moved from the namespace of the current context of the source kubeconfig,
+	// without falling back to the default namespace; an error is returned if the context does not define a namespace.
+	// FromContextNamespace can't be used together with Namespace, Namespaces or AllNamespaces.
+	FromContextNamespace bool
+
+	// ClusterName restricts the move to the Cluster with the given name and to all the objects depending on it;
+	// objects not belonging to this Cluster are left in the source management cluster. If unspecified, all the
+	// Clusters in the namespace are moved.
+	ClusterName string
+
+	// LabelSelector restricts the move to the Clusters matching the given label selector (e.g. environment=staging)
+	// and to all the objects depending on them. If unspecified, all the Clusters in the namespace are moved.
+	LabelSelector string
+
+	// SharedOnly restricts the move to the objects shared by many Clusters, i.e. the cluster-scoped objects and the namespaced
+	// objects labeled with clusterctl.cluster.x-k8s.io/move-shared, and to all the objects depending on them, e.g. for
+	// preparing the target management cluster before moving the Clusters. SharedOnly can't be used together with ClusterName
+	// or LabelSelector.
+	SharedOnly bool
+
+	// RootKind, if set, is the kind of the objects, in the group/kind format (e.g. cluster.x-k8s.io/MachineDeployment), to be
+	// moved together with all the objects depending on them in place of the Clusters, e.g. for specialized recovery scenarios.
+	// The Clusters and all the other objects the moved objects depend on are left in the source management cluster, so the moved
+	// objects are going to reference objects missing in the target management cluster; the Clusters owning the moved objects
+	// must be paused, e.g. using PauseOnly, and are left paused. RootKind can't be used together with SharedOnly.
+	RootKind string
+
+	// ExcludeKinds lists the kinds of the objects, in the group/kind format (e.g. ipam.cluster.x-k8s.io/IPPool), that should
+	// not be moved and thus left in the source management cluster. Kinds in the core group can be specified without group.
+	ExcludeKinds []string
+
+	// StrictDiscovery means that the move fails if a kind defined by the CRDs installed by clusterctl is not served by the
+	// source management cluster, e.g. because a provider was partially uninstalled; otherwise the kind is skipped and
+	// reported in the SkippedKinds field of the summary.
+	StrictDiscovery bool
+
+	// ExternalInfrastructure means that the infrastructure objects managed by an external system, i.e. with the
+	// cluster.x-k8s.io/managed-by annotation, are left in the source management cluster, while the objects referencing them,
+	// e.g. the Clusters, are moved; the externally managed objects must already exist in the target management cluster.
+	ExternalInfrastructure bool
+
+	// Parallelism defines the maximum number of independent objects that are created or deleted concurrently;
+	// objects are still processed respecting the order defined by their dependencies. If unspecified, objects
+	// are processed one at a time.
+	Parallelism int
+
+	// StateFile is the path of a file where the progress of the move is recorded, so an interrupted move can be resumed.
+	// StateFile is supported only when moving objects between management clusters.
+	StateFile string
+
+	// Resume instructs move to resume an interrupted move using the progress recorded in StateFile.
+	Resume bool
+
+	// Timeout defines the maximum duration of the move; once the timeout expires, the move is aborted
+	// without starting any further change. If unspecified, no timeout applies.
+	Timeout time.Duration
+
+	// ToNamespace, if set, defines the namespace in the target management cluster where the objects are moved to,
+	// instead of using the same namespace of the source management cluster. The namespace must already exist.
+	ToNamespace string
+
+	// RewriteRefs maps namespaces in the source management cluster to namespaces in the target management cluster, e.g.
+	// capa-system to capa-prod, for remapping the references to objects in other namespaces when moving objects to ToNamespace;
+	// references to other namespaces that can't be resolved in the target management cluster are logged as warnings.
+	RewriteRefs map[string]string
+
+	// RewriteFinalizers maps finalizers of the objects in the source management cluster to the finalizers to be set on the
+	// objects created in the target management cluster, e.g. for finalizers referencing a controller by an instance-specific
+	// name; finalizers mapped to an empty string are removed, while finalizers not listed, including the Cluster API ones,
+	// are left untouched.
+	RewriteFinalizers map[string]string
+
+	// RenameClusters maps the names of Clusters in the source management cluster to new names in the target management
+	// cluster, e.g. for cloning a Cluster to a lab management cluster without confusion; the Secrets named after the Cluster,
+	// the OwnerReferences, the clusterName fields, the cluster.x-k8s.io/cluster-name labels and the other references to
+	// the renamed objects are updated accordingly.
+	RenameClusters map[string]string
+
+	// ValidateOnly means that only the preflight checks are performed, verifying that the providers and the CRDs in the
+	// source management cluster are installed in the target management cluster too, without moving any object.
+	ValidateOnly bool
+
+	// ListObjects means that only the objects that would be moved are discovered and listed in the summary, without
+	// checking them, without computing the move sequence and without accessing the target management cluster.
+	ListObjects bool
+
+	// TargetReadyTimeout defines how long to wait for the providers in the target management cluster to be available
+	// before pausing the source objects, or before restoring the objects from a directory or an archive. If unspecified,
+	// a default of 5 minutes is used.
+	TargetReadyTimeout time.Duration
+
+	// DeleteTimeout defines how long to wait for the objects deleted from the source management cluster to actually disappear;
+	// if some objects, e.g. blocked by finalizers, still exist after this time, the move fails listing them.
+	// If unspecified, a default of 5 minutes is used.
+	DeleteTimeout time.Duration
+
+	// SkipExisting means that the objects already existing in the target management cluster, e.g. after a partial manual
+	// migration, are left untouched instead of being overwritten with the objects read from the source; each skipped object
+	// is logged.
+	SkipExisting bool
+
+	// VerifyObjects means that each object created or updated in the target management cluster is read back and compared with
+	// the object move intended to create, ignoring the fields managed by the server, e.g. the status; drift, e.g. caused by
+	// defaulting or mutating webhooks, is logged and reported in the Drift field of the MoveReport without failing the move.
+	VerifyObjects bool
+
+	// AnnotateProvenance means that the objects created in the target management cluster are annotated with the kubeconfig
+	// context of the source management cluster, or the directory the objects are restored from, and with the time of the move,
+	// e.g. for auditing which management cluster a workload cluster originated from after several migrations.
+	AnnotateProvenance bool
+
+	// MovedFromAnnotation and MovedAtAnnotation override the keys of the provenance annotations, by default
+	// clusterctl.cluster.x-k8s.io/moved-from and clusterctl.cluster.x-k8s.io/moved-at.
+	MovedFromAnnotation string
+	MovedAtAnnotation   string
+
+	// SkipVerify means that objects are restored from FromDirectory or FromArchive without verifying their checksum against the manifest
+	// written when saving them; by default, tampered or partial backups are not restored.
+	SkipVerify bool
+
+	// GraphOutput, if set, defines the path of a file where the objects to be moved and their dependencies are written
+	// in the Graphviz DOT format; in combination with DryRun, this allows to review the move plan before executing it.
+	GraphOutput string
+
+	// Retries defines how many times creating or deleting an object is retried after a transient error, e.g. a server timeout
+	// or a network error; other errors, e.g. an invalid object, fail immediately. If zero, failures are not retried.
+	Retries int
+
+	// RetryBackoff defines the initial delay before retrying after a transient error; the delay grows exponentially at each retry.
+	// If unspecified, a default of 500ms is used.
+	RetryBackoff time.Duration
+
+	// PauseOnly means that only the pause phase of the move is performed, i.e. the Clusters in the source management cluster
+	// are paused without moving any object. PauseOnly and UnpauseOnly are mutually exclusive.
+	PauseOnly bool
+
+	// UnpauseOnly means that only the unpause phase of the move is performed, i.e. the reconciliation of the Clusters in the source
+	// management cluster is resumed without moving any object, e.g. for recovering from a failed move.
+	UnpauseOnly bool
+
+	// ContinueOnError means that the remaining objects are moved even if creating or deleting some objects fails; all the
+	// errors are returned at the end. Objects depending on a failed object are not moved, and all the objects belonging to a
+	// Cluster with failed objects are kept in the source management cluster, with the Cluster paused in both management clusters.
+	ContinueOnError bool
+
+	// CopyOnly means that the objects are created in the target management cluster without pausing and deleting them in the
+	// source management cluster, e.g. for cloning Clusters to a lab management cluster. Because both management clusters are
+	// going to reconcile the same infrastructure, CopyOnly requires Transformers or TransformFile, e.g. for changing the
+	// credentials used by the copied objects, or AllowUnsafeCopy.
+	CopyOnly bool
+
+	// AllowUnsafeCopy acknowledges that, when CopyOnly is set without transforming the objects, both management clusters
+	// are going to reconcile the same infrastructure.
+	AllowUnsafeCopy bool
+
+	// PreservePaused means that the Clusters already paused before the move are kept paused at the end of the move, instead
+	// of resuming the reconciliation of all the moved Clusters, e.g. for Clusters intentionally frozen in the source
+	// management cluster.
+	PreservePaused bool
+
+	// Transformers are applied, in order, to each object before creating it in the target management cluster, e.g. for
+	// swapping the name of an identity Secret or changing a region label specific to an environment; the apiVersion, kind,
+	// namespace and name of the objects can't be changed.
+	Transformers []ObjectTransformer
+
+	// TransformFile, if set, defines the path of a file with rules setting fields on the objects before creating them in the
+	// target management cluster; the rules are applied after Transformers.
+	TransformFile string
+
+	// WaitForCompletion means that, once all the objects are moved, move waits for the moved Clusters to be ready and for
+	// the moved Machines to have a NodeRef in the target management cluster, e.g. for gating automated migrations on the
+	// workload clusters being reconciled again by the target management cluster.
+	WaitForCompletion bool
+
+	// WaitForCompletionTimeout defines how long to wait for the moved Clusters and Machines to be provisioned in the target
+	// management cluster; if some objects are not provisioned after this time, the move fails listing them.
+	// If unspecified, a default of 10 minutes is used.
+	WaitForCompletionTimeout time.Duration
+
+	// DryRun means the move action is a dry run, no real action will be performed; the list of objects
+	// that would be moved is printed instead. When DryRun is set, ToKubeconfig is not required.
+	DryRun bool
+}
+
+// SyncOptions carries the options supported by sync.
+type SyncOptions struct {
+	// FromKubeconfig defines the kubeconfig file to use for accessing the source management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	FromKubeconfig string
+
+	// FromKubeconfigContext defines the context within FromKubeconfig to use for accessing the source management cluster.
+	// If empty, the current context will be used.
+	FromKubeconfigContext string
+
+	// ToKubeconfig defines the path to the kubeconfig file to use for accessing the target management cluster.
+	ToKubeconfig string
+
+	// ToKubeconfigContext defines the context within ToKubeconfig to use for accessing the target management cluster.
+	// If empty, the current context will be used.
+	ToKubeconfigContext string
+
+	// Namespace where the objects describing the workload cluster exists. If unspecified, the namespace of the current
+	// context of the source kubeconfig will be used; if the context does not define a namespace, the default namespace is used.
+	Namespace string
+
+	// Namespaces lists the namespaces where the objects describing the workload clusters exist, for syncing related clusters
+	// hosted in many namespaces in a single operation. Namespaces can't be used together with Namespace.
+	Namespaces []string
+
+	// AllNamespaces means the objects are synced from all the namespaces, making this intent explicit.
+	// AllNamespaces can't be used together with Namespace or Namespaces.
+	AllNamespaces bool
+
+	// FromContextNamespace means the objects are synced from the namespace of the current context of the source kubeconfig,
+	// without falling back to the default namespace; an error is returned if the context does not define a namespace.
+	// FromContextNamespace can't be used together with Namespace, Namespaces or AllNamespaces.
+	FromContextNamespace bool
+
+	// ClusterName restricts the sync to the Cluster with the given name and to all the objects depending on it.
+	// If unspecified, all the Clusters in the namespace are synced.
+	ClusterName string
+
+	// LabelSelector restricts the sync to the Clusters matching the given label selector and to all the objects depending
+	// on them. If unspecified, all the Clusters in the namespace are synced.
+	LabelSelector string
+
+	// ExcludeKinds lists the kinds of the objects, in the group/kind format, that should not be synced.
+	ExcludeKinds []string
+
+	// Since, if set, restricts the sync to the objects modified after the given time, according to the latest time recorded in
+	// their managedFields, and to the objects they depend on, e.g. for cheaply re-syncing a large, mostly unchanged management
+	// cluster to a warm-standby one after the previous sync.
+	Since time.Time
+
+	// StrictDiscovery means that the sync fails if a kind defined by the CRDs installed by clusterctl is not served by the
+	// source management cluster, instead of skipping the kind.
+	StrictDiscovery bool
+
+	// Parallelism defines the maximum number of independent objects that are created or updated concurrently.
+	// If unspecified, objects are processed one at a time.
+	Parallelism int
+
+	// Timeout defines the maximum duration of the sync; once the timeout expires, the sync is aborted
+	// without starting any further change. If unspecified, no timeout applies.
+	Timeout time.Duration
+
+	// TargetReadyTimeout defines how long to wait for the providers in the target management cluster to be available
+	// before syncing. If unspecified, a default of 5 minutes is used.
+	TargetReadyTimeout time.Duration
+
+	// Retries and RetryBackoff define how many times, and with which initial delay, creating or updating an object is
+	// retried after a transient error. If zero, failures are not retried; if RetryBackoff is unspecified, a default of 500ms is used.
+	Retries      int
+	RetryBackoff time.Duration
+
+	// Transformers are applied, in order, to each object before creating it in the target management cluster, e.g. for
+	// swapping the name of an identity Secret or changing a region label specific to an environment; the apiVersion, kind,
+	// namespace and name of the objects can't be changed.
+	Transformers []ObjectTransformer
+
+	// TransformFile, if set, defines the path of a file with rules setting fields on the objects before creating them in the
+	// target management cluster; the rules are applied after Transformers.
+	TransformFile string
+
+	// ContinueOnError means that the remaining objects are synced even if creating or updating some objects fails;
+	// all the errors are returned at the end.
+	ContinueOnError bool
+
+	// Diff means the sync only reports, for each object, whether it would be created, updated or left unchanged in the
+	// target management cluster, together with the patch of the updated objects, without making any change.
+	// Diff can't be set together with DryRun, because the objects are compared with the target management cluster.
+	Diff bool
+
+	// VerifyObjects means that each object created or updated in the target management cluster is read back and compared with
+	// the object sync intended to create; drift is reported in the Drift field of the MoveReport. VerifyObjects can't be set
+	// together with Diff.
+	VerifyObjects bool
+
+	// DryRun means the sync is a dry run, no real action will be performed; the list of objects
+	// that would be synced is printed instead. When DryRun is set, ToKubeconfig is not required.
+	DryRun bool
+}
+
+// DiscoverMoveGraphOptions carries the options supported by DiscoverMoveGraph.
+type DiscoverMoveGraphOptions struct {
+	// FromKubeconfig defines the kubeconfig file to use for accessing the source management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	FromKubeconfig string
+
+	// FromKubeconfigContext defines the context within FromKubeconfig to use for accessing the source management cluster.
+	// If empty, the current context will be used.
+	FromKubeconfigContext string
+
+	// Namespace where the objects describing the workload cluster exists. If unspecified, the namespace of the current
+	// context of the source kubeconfig will be used; if the context does not define a namespace, the default namespace is used.
+	Namespace string
+
+	// Namespaces lists the namespaces where the objects describing the workload clusters exist.
+	// Namespaces can't be used together with Namespace.
+	Namespaces []string
+
+	// AllNamespaces means the objects are discovered in all the namespaces, making this intent explicit.
+	// AllNamespaces can't be used together with Namespace or Namespaces.
+	AllNamespaces bool
+
+	// ClusterName restricts the graph to the Cluster with the given name and to all the objects depending on it.
+	ClusterName string
+
+	// LabelSelector restricts the graph to the Clusters matching the given label selector and to all the objects depending on them.
+	LabelSelector string
+
+	// ExcludeKinds lists the kinds of the objects, in the group/kind format, that should not be included in the graph.
+	ExcludeKinds []string
+
+	// StrictDiscovery means that the discovery fails if a kind defined by the CRDs installed by clusterctl is not served by the
+	// source management cluster, instead of skipping the kind.
+	StrictDiscovery bool
 }
 
 // Client is exposes the clusterctl high-level client library.
@@ -92,6 +452,22 @@ type Client interface {
 	// Move moves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster.
 	Move(options MoveOptions) error
 
+	// MoveWithReport moves all the Cluster API objects like Move, returning a report of the objects moved, skipped or failed and
+	// of the duration of each phase of the move. If the move fails while processing objects, the report is returned together
+	// with the error.
+	MoveWithReport(options MoveOptions) (*MoveReport, error)
+
+	// Sync creates or updates all the Cluster API objects existing in a namespace (or from all the namespaces if empty) in a target
+	// management cluster, without pausing or deleting them in the source management cluster, e.g. for keeping a warm-standby
+	// management cluster; Clusters are kept paused in the target management cluster, and objects not changed since the previous
+	// sync are left untouched. Sync returns the same report returned by MoveWithReport.
+	Sync(options SyncOptions) (*MoveReport, error)
+
+	// DiscoverMoveGraph returns the graph of the Cluster API objects existing in a namespace (or in all the namespaces if empty)
+	// that would be moved, with their dependencies and the order they would be moved in, e.g. for tools planning or validating
+	// a move operation. No change is made to the management cluster.
+	DiscoverMoveGraph(options DiscoverMoveGraphOptions) (*MoveGraph, error)
+
 	// PlanUpgrade returns a set of suggested Upgrade plans for the cluster, and more specifically:
 	// - Each management group gets separated upgrade plans.
 	// - For each management group, an upgrade plan is generated for each API Version of Cluster API (contract) available, e.g.
@@ -111,7 +487,7 @@ type clusterctlClient struct {
 }
 
 type RepositoryClientFactory func(config.Provider) (repository.Client, error)
-type ClusterClientFactory func(string) (cluster.Client, error)
+type ClusterClientFactory func(cluster.Kubeconfig) (cluster.Client, error)
 
 // Ensure clusterctlClient implements Client.
 var _ Client = &clusterctlClient{}
@@ -177,8 +553,8 @@ func newClusterctlClient(path string, options ...Option) (*clusterctlClient, err
 }
 
 // defaultClusterFactory is a ClusterClientFactory func the uses the default client provided by the cluster low level library.
-func defaultClusterFactory(configClient config.Client) func(kubeconfig string) (cluster.Client, error) {
-	return func(kubeconfig string) (cluster.Client, error) {
+func defaultClusterFactory(configClient config.Client) func(kubeconfig cluster.Kubeconfig) (cluster.Client, error) {
+	return func(kubeconfig cluster.Kubeconfig) (cluster.Client, error) {
 		return cluster.New(kubeconfig, configClient), nil
 	}
 }
@@ -49,7 +49,7 @@ func TestNewFakeClient(t *testing.T) {
 		WithFile("v1.0", "components.yaml", []byte("content"))
 
 	// create a fake cluster, eventually adding some existing runtime objects to it
-	cluster1 := newFakeCluster("cluster1", config1).
+	cluster1 := newFakeCluster(cluster.Kubeconfig{Path: "cluster1"}, config1).
 		WithObjs()
 
 	// create a new fakeClient that allows to execute tests on the fake config, the fake repositories and the fake cluster.
@@ -60,7 +60,7 @@ func TestNewFakeClient(t *testing.T) {
 
 type fakeClient struct {
 	configClient   config.Client
-	clusters       map[string]cluster.Client
+	clusters       map[cluster.Kubeconfig]cluster.Client
 	repositories   map[string]repository.Client
 	internalClient *clusterctlClient
 }
@@ -95,6 +95,18 @@ func (f fakeClient) Move(options MoveOptions) error {
 	return f.internalClient.Move(options)
 }
 
+func (f fakeClient) MoveWithReport(options MoveOptions) (*MoveReport, error) {
+	return f.internalClient.MoveWithReport(options)
+}
+
+func (f fakeClient) Sync(options SyncOptions) (*MoveReport, error) {
+	return f.internalClient.Sync(options)
+}
+
+func (f fakeClient) DiscoverMoveGraph(options DiscoverMoveGraphOptions) (*MoveGraph, error) {
+	return f.internalClient.DiscoverMoveGraph(options)
+}
+
 func (f fakeClient) PlanUpgrade(options PlanUpgradeOptions) ([]UpgradePlan, error) {
 	return f.internalClient.PlanUpgrade(options)
 }
@@ -108,7 +120,7 @@ func (f fakeClient) ApplyUpgrade(options ApplyUpgradeOptions) error {
 func newFakeClient(configClient config.Client) *fakeClient {
 
 	fake := &fakeClient{
-		clusters:     map[string]cluster.Client{},
+		clusters:     map[cluster.Kubeconfig]cluster.Client{},
 		repositories: map[string]repository.Client{},
 	}
 
@@ -117,7 +129,7 @@ func newFakeClient(configClient config.Client) *fakeClient {
 		fake.configClient = newFakeConfig()
 	}
 
-	var clusterClientFactory = func(kubeconfig string) (cluster.Client, error) {
+	var clusterClientFactory = func(kubeconfig cluster.Kubeconfig) (cluster.Client, error) {
 		if _, ok := fake.clusters[kubeconfig]; !ok {
 			return nil, errors.Errorf("Cluster for kubeconfig %q does not exists.", kubeconfig)
 		}
@@ -154,7 +166,7 @@ func (f *fakeClient) WithRepository(repositoryClient repository.Client) *fakeCli
 // newFakeCluster returns a fakeClusterClient that
 // internally uses a FakeProxy (based on the controller-runtime FakeClient).
 // You can use WithObjs to pre-load a set of runtime objects in the cluster.
-func newFakeCluster(kubeconfig string, configClient config.Client) *fakeClusterClient {
+func newFakeCluster(kubeconfig cluster.Kubeconfig, configClient config.Client) *fakeClusterClient {
 	fake := &fakeClusterClient{
 		kubeconfig:   kubeconfig,
 		repositories: map[string]repository.Client{},
@@ -165,7 +177,7 @@ func newFakeCluster(kubeconfig string, configClient config.Client) *fakeClusterC
 		return nil
 	}
 
-	fake.internalclient = cluster.New("", configClient,
+	fake.internalclient = cluster.New(cluster.Kubeconfig{}, configClient,
 		cluster.InjectProxy(fake.fakeProxy),
 		cluster.InjectPollImmediateWaiter(pollImmediateWaiter),
 		cluster.InjectRepositoryFactory(func(provider config.Provider, configClient config.Client, options ...repository.Option) (repository.Client, error) {
@@ -195,7 +207,7 @@ func (p *fakeCertManagerClient) Images() ([]string, error) {
 }
 
 type fakeClusterClient struct {
-	kubeconfig     string
+	kubeconfig     cluster.Kubeconfig
 	fakeProxy      *test.FakeProxy
 	repositories   map[string]repository.Client
 	internalclient cluster.Client
@@ -203,7 +215,7 @@ type fakeClusterClient struct {
 
 var _ cluster.Client = &fakeClusterClient{}
 
-func (f fakeClusterClient) Kubeconfig() string {
+func (f fakeClusterClient) Kubeconfig() cluster.Kubeconfig {
 	return f.kubeconfig
 }
 
@@ -34,14 +34,23 @@ var (
 	ctx = context.TODO()
 )
 
+// Kubeconfig identifies the kubeconfig file and the context within it to be used for accessing a management cluster.
+type Kubeconfig struct {
+	// Path to the kubeconfig file. If empty, default discovery rules apply.
+	Path string
+
+	// Context within the kubeconfig file. If empty, the current context is used.
+	Context string
+}
+
 // Client is used to interact with a management cluster.
 // A management cluster contains following categories of objects:
 // - provider components (e.g. the CRDs, controllers, RBAC)
 // - provider inventory items (e.g. the list of installed providers/versions)
 // - provider objects (e.g. clusters, AWS clusters, machines etc.)
 type Client interface {
-	// Kubeconfig return the path to kubeconfig used to access to a management cluster.
-	Kubeconfig() string
+	// Kubeconfig return the kubeconfig used to access to a management cluster.
+	Kubeconfig() Kubeconfig
 
 	// Proxy return the Proxy used for operating objects in the management cluster.
 	Proxy() Proxy
@@ -79,7 +88,7 @@ type PollImmediateWaiter func(interval, timeout time.Duration, condition wait.Co
 // clusterClient implements Client.
 type clusterClient struct {
 	configClient            config.Client
-	kubeconfig              string
+	kubeconfig              Kubeconfig
 	proxy                   Proxy
 	repositoryClientFactory RepositoryClientFactory
 	pollImmediateWaiter     PollImmediateWaiter
@@ -90,7 +99,7 @@ type RepositoryClientFactory func(provider config.Provider, configClient config.
 // ensure clusterClient implements Client.
 var _ Client = &clusterClient{}
 
-func (c *clusterClient) Kubeconfig() string {
+func (c *clusterClient) Kubeconfig() Kubeconfig {
 	return c.kubeconfig
 }
 
@@ -115,7 +124,7 @@ func (c *clusterClient) ProviderInstaller() ProviderInstaller {
 }
 
 func (c *clusterClient) ObjectMover() ObjectMover {
-	return newObjectMover(c.proxy, c.ProviderInventory())
+	return newObjectMover(c.kubeconfig, c.proxy, c.ProviderInventory(), c.pollImmediateWaiter)
 }
 
 func (c *clusterClient) ProviderUpgrader() ProviderUpgrader {
@@ -152,11 +161,11 @@ func InjectPollImmediateWaiter(pollImmediateWaiter PollImmediateWaiter) Option {
 }
 
 // New returns a cluster.Client.
-func New(kubeconfig string, configClient config.Client, options ...Option) Client {
+func New(kubeconfig Kubeconfig, configClient config.Client, options ...Option) Client {
 	return newClusterClient(kubeconfig, configClient, options...)
 }
 
-func newClusterClient(kubeconfig string, configClient config.Client, options ...Option) *clusterClient {
+func newClusterClient(kubeconfig Kubeconfig, configClient config.Client, options ...Option) *clusterClient {
 	client := &clusterClient{
 		configClient: configClient,
 		kubeconfig:   kubeconfig,
@@ -187,6 +196,10 @@ type Proxy interface {
 	// CurrentNamespace returns the namespace from the current context in the kubeconfig file
 	CurrentNamespace() (string, error)
 
+	// ContextNamespace returns the namespace defined in the current context in the kubeconfig file, or an empty string
+	// if the context does not define a namespace.
+	ContextNamespace() (string, error)
+
 	// NewClient returns a new controller runtime Client object for working on the management cluster
 	NewClient() (client.Client, error)
 
@@ -198,13 +211,29 @@ var _ Proxy = &test.FakeProxy{}
 
 // retryWithExponentialBackoff repeats an operation until it passes or the exponential backoff times out.
 func retryWithExponentialBackoff(opts wait.Backoff, operation func() error) error { //nolint:unparam
+	return retryWithExponentialBackoffContext(ctx, opts, operation)
+}
+
+// retryWithExponentialBackoffContext repeats an operation until it passes, the exponential backoff times out or the context is done.
+func retryWithExponentialBackoffContext(ctx context.Context, opts wait.Backoff, operation func() error) error {
+	return retryWithExponentialBackoffContextIf(ctx, opts, func(error) bool { return true }, operation)
+}
+
+// retryWithExponentialBackoffContextIf repeats an operation until it passes, the exponential backoff times out or the context is done;
+// errors for which isRetriable returns false are returned immediately, without further attempts.
+func retryWithExponentialBackoffContextIf(ctx context.Context, opts wait.Backoff, isRetriable func(error) bool, operation func() error) error {
 	log := logf.Log
 
 	i := 0
+	var failFastErr error
 	err := wait.ExponentialBackoff(opts, func() (bool, error) {
 		i++
 		if err := operation(); err != nil {
-			if i < opts.Steps {
+			if !isRetriable(err) {
+				failFastErr = err
+				return false, err
+			}
+			if i < opts.Steps && ctx.Err() == nil {
 				log.V(5).Info("Operation failed, retry", "Error", err)
 				return false, nil
 			}
@@ -212,6 +241,9 @@ func retryWithExponentialBackoff(opts wait.Backoff, operation func() error) erro
 		}
 		return true, nil
 	})
+	if failFastErr != nil {
+		return failFastErr
+	}
 	if err != nil {
 		return errors.Wrapf(err, "action failed after %d attempts", i)
 	}
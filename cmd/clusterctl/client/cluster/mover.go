@@ -17,82 +17,1377 @@ limitations under the License.
 package cluster
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"math"
+	"net"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeversion "k8s.io/apimachinery/pkg/version"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	utilyaml "sigs.k8s.io/cluster-api/cmd/clusterctl/internal/util"
 	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	waitTargetReadyInterval   = 5 * time.Second
+	defaultTargetReadyTimeout = 5 * time.Minute
+
+	waitSourceDeletedInterval    = 500 * time.Millisecond
+	maxWaitSourceDeletedInterval = 10 * time.Second
+	defaultDeleteTimeout         = 5 * time.Minute
+
+	waitForCompletionInterval       = 10 * time.Second
+	defaultWaitForCompletionTimeout = 10 * time.Minute
+)
+
+// MoveOptions carries the options supported by ObjectMover.Move.
+type MoveOptions struct {
+	// Namespace where the objects to be moved exist. If empty, objects from all the namespaces are moved.
+	Namespace string
+
+	// Namespaces lists the namespaces where the objects to be moved exist, for moving the objects from many namespaces in a
+	// single operation, so the references between them are preserved. Namespaces can't be used together with Namespace.
+	Namespaces []string
+
+	// ClusterName restricts the move to the Cluster with the given name and to all the objects depending on it.
+	// If empty, all the Clusters are moved.
+	ClusterName string
+
+	// LabelSelector restricts the move to the Clusters matching the given label selector and to all the objects depending on them.
+	// If empty, all the Clusters are moved.
+	LabelSelector string
+
+	// SharedOnly restricts the move to the shared objects, i.e. the cluster-scoped objects and the namespaced objects with the
+	// move-shared label, and to all the objects depending on them; objects belonging to a Cluster are not moved.
+	// SharedOnly can't be used together with ClusterName or LabelSelector.
+	SharedOnly bool
+
+	// VerifyObjects instructs move to read back each object created or updated in the target management cluster and to
+	// compare it with the object move intended to create, ignoring the fields managed by the server, e.g. the status; drift,
+	// e.g. caused by defaulting or mutating webhooks, is logged and recorded in the summary without failing the move.
+	VerifyObjects bool
+
+	// RootKind, if set, is the kind of the objects, in the group/kind format, to be moved together with their dependents in
+	// place of the Clusters, e.g. cluster.x-k8s.io/MachineDeployment; the Clusters and all the other objects the moved objects
+	// depend on are left in the source management cluster, so the moved objects are going to reference objects that do not exist
+	// in the target management cluster. The Clusters owning the moved objects must be paused. RootKind can be used together
+	// with ClusterName or LabelSelector, but not together with SharedOnly.
+	RootKind string
+
+	// ExternalInfrastructure instructs move to leave in the source management cluster the infrastructure objects
+	// managed by an external system, i.e. with the cluster.x-k8s.io/managed-by annotation, while still moving the objects
+	// referencing them, e.g. the Clusters; the externally managed objects must already exist in the target management cluster.
+	ExternalInfrastructure bool
+
+	// ExcludeKinds lists the kinds of the objects, in the group/kind format, that should be left in the source management cluster;
+	// e.g. infrastructure.cluster.x-k8s.io/DummyInfrastructureMachine. Kinds in the core group can be specified without group, e.g. Secret.
+	ExcludeKinds []string
+
+	// Since, if set, restricts the sync to the objects modified after the given time, according to the latest time recorded in
+	// their managedFields, and to the objects they depend on, so the references of the modified objects are satisfied in the
+	// target management cluster; objects without managedFields are always synced. Since is used only by Sync.
+	Since time.Time
+
+	// Parallelism defines the maximum number of objects in the same step of the move sequence that are processed concurrently;
+	// objects in the same step do not depend on each other. If less than 1, objects are processed one at a time.
+	Parallelism int
+
+	// StateFile is the path of a file where the progress of the move operation is recorded, so an interrupted move can be resumed.
+	// The file is deleted once the move operation completes.
+	StateFile string
+
+	// Resume instructs move to resume an interrupted move operation using the progress recorded in StateFile, skipping
+	// the objects already created in the target management cluster or already deleted from the source management cluster.
+	Resume bool
+
+	// Timeout defines the maximum duration of the move operation; once the timeout expires, the move operation is aborted
+	// without starting any further change. If zero, no timeout applies.
+	Timeout time.Duration
+
+	// ToNamespace, if set, defines the namespace in the target management cluster where the objects are moved to;
+	// the namespace must already exist. References between the moved objects are preserved, while cluster-scoped
+	// objects and references to objects in other namespaces are left untouched, unless remapped by RewriteRefs.
+	ToNamespace string
+
+	// RewriteRefs maps namespaces in the source management cluster to namespaces in the target management cluster, for
+	// remapping the references to objects in other namespaces, e.g. to a Secret referenced by an identityRef, when the
+	// objects are moved to ToNamespace. RewriteRefs can't include the namespace the objects are moved from.
+	RewriteRefs map[string]string
+
+	// RewriteFinalizers maps finalizers of the objects in the source management cluster to the finalizers set on the objects
+	// created in the target management cluster, e.g. for finalizers referencing a controller by an instance-specific name;
+	// finalizers mapped to an empty string are removed. Finalizers not listed, including the Cluster API ones, are left untouched.
+	RewriteFinalizers map[string]string
+
+	// RenameClusters maps the names of Clusters in the source management cluster to the names of the corresponding Clusters
+	// in the target management cluster, e.g. for cloning a Cluster to a lab management cluster; the Secrets named after the
+	// Cluster, e.g. <cluster-name>-kubeconfig, are renamed too, together with the OwnerReferences, the clusterName fields, the
+	// cluster.x-k8s.io/cluster-name labels and the other references to the renamed objects.
+	RenameClusters map[string]string
+
+	// StrictDiscovery makes the discovery fail when a kind defined by the CRDs installed by clusterctl is not served by the
+	// source management cluster, e.g. because a provider was partially uninstalled; otherwise the kind is skipped, logging a
+	// warning, and reported in the SkippedKinds field of the summary.
+	StrictDiscovery bool
+
+	// ValidateOnly instructs move to perform only the preflight checks, i.e. to check that the providers and the CRDs
+	// installed in the source management cluster are installed in the target management cluster too and that all the
+	// objects are ready to be moved, without making any change to the source or the target management cluster.
+	ValidateOnly bool
+
+	// TargetReadyTimeout defines how long to wait for the providers in the target management cluster to be available before
+	// starting the move; if they are not available within this time, the move is aborted before pausing the source objects.
+	// If zero, a default of 5 minutes is used.
+	TargetReadyTimeout time.Duration
+
+	// DeleteTimeout defines how long to wait for the objects deleted from the source management cluster to actually disappear,
+	// e.g. when a controller adds back a finalizer; if some objects still exist after this time, the move fails listing them.
+	// If zero, a default of 5 minutes is used.
+	DeleteTimeout time.Duration
+
+	// SkipExisting instructs move to leave untouched the objects already existing in the target management cluster, e.g. after
+	// a partial manual migration, instead of overwriting them with the objects read from the source.
+	SkipExisting bool
+
+	// AnnotateProvenance instructs move to annotate the objects created in the target management cluster with the kubeconfig
+	// context of the source management cluster, or the directory the objects are restored from, and with the time of the move.
+	AnnotateProvenance bool
+
+	// MovedFromAnnotation is the key of the annotation recording where the objects were moved from, if AnnotateProvenance is set.
+	// If empty, clusterctl.cluster.x-k8s.io/moved-from is used.
+	MovedFromAnnotation string
+
+	// MovedAtAnnotation is the key of the annotation recording when the objects were moved, if AnnotateProvenance is set.
+	// If empty, clusterctl.cluster.x-k8s.io/moved-at is used.
+	MovedAtAnnotation string
+
+	// SkipVerify instructs FromDirectory to restore the objects saved in a directory without verifying their checksum
+	// against the manifest written by ToDirectory.
+	SkipVerify bool
+
+	// GraphOutput, if set, is the path of a file where the objects that are going to be moved and their dependencies are
+	// written in the Graphviz DOT format, e.g. for reviewing the move plan in combination with DryRun.
+	GraphOutput string
+
+	// Retries defines how many times creating an object in the target management cluster or deleting an object from the
+	// source management cluster is retried after a transient error, e.g. a server timeout; other errors fail immediately.
+	// If zero, failures are not retried.
+	Retries int
+
+	// RetryBackoff defines the initial delay before retrying after a transient error; the delay grows exponentially at each retry.
+	// If zero, a default of 500ms is used.
+	RetryBackoff time.Duration
+
+	// ContinueOnError instructs move to keep processing the remaining objects when creating or deleting an object fails,
+	// returning all the errors at the end. Objects depending on a failed object are skipped, and all the objects belonging to
+	// a Cluster with failed objects are kept in the source management cluster, with the Cluster paused in both management clusters.
+	ContinueOnError bool
+
+	// CopyOnly instructs move to create the objects in the target management cluster without pausing and deleting them in
+	// the source management cluster, e.g. for cloning Clusters to a lab management cluster. Both management clusters reconcile
+	// the same infrastructure, unless the objects are changed, e.g. by Transformers, to use different credentials.
+	CopyOnly bool
+
+	// PreservePaused instructs move to keep paused, at the end of the move, the Clusters that were already paused before the
+	// move, instead of resuming the reconciliation of all the moved Clusters; when saving the objects to a directory or to an
+	// archive, the same applies to the Clusters in the source management cluster.
+	PreservePaused bool
+
+	// Transformers are applied, in order, to each object read from the source management cluster, or from the directory the
+	// objects are restored from, before creating it in the target management cluster, e.g. for rewriting fields specific to an
+	// environment; transformers run before the namespace and the OwnerReferences are remapped, and can't change the identity
+	// of the objects.
+	Transformers []ObjectTransformer
+
+	// WaitForCompletion instructs move, once all the objects are moved, to wait for the moved Clusters to be ready and for the
+	// moved Machines to have a NodeRef in the target management cluster, i.e. to be reconciled again by the target controllers.
+	WaitForCompletion bool
+
+	// WaitForCompletionTimeout defines how long to wait for the moved Clusters and Machines to be provisioned in the target
+	// management cluster, if WaitForCompletion is set; if some objects are not provisioned after this time, the move fails
+	// listing them. If zero, a default of 10 minutes is used.
+	WaitForCompletionTimeout time.Duration
+
+	// Diff instructs Sync to compute, for each object, whether it would be created, updated or left unchanged in the target
+	// management cluster, reporting it in the summary together with the patch of the updated objects, without making any change.
+	Diff bool
+
+	// DryRun instructs move to perform only the discovery and ordering phases, printing the list of objects
+	// that would be moved without making any change to the source or the target management cluster.
+	DryRun bool
+}
+
 // ObjectMover defines methods for moving Cluster API objects to another management cluster.
+// All the methods return a summary reporting the objects processed and the duration of each phase of the operation;
+// if Move or FromDirectory fail while processing objects, the summary is returned together with the error, reporting
+// the objects processed so far and the ones that failed.
 type ObjectMover interface {
 	// Move moves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster.
-	Move(namespace string, toCluster Client) error
+	// When running in dry-run mode, toCluster can be nil.
+	Move(toCluster Client, options MoveOptions) (*MoveSummary, error)
+
+	// ToDirectory saves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target directory,
+	// one YAML file for each object.
+	ToDirectory(directory string, options MoveOptions) (*MoveSummary, error)
+
+	// FromDirectory restores all the Cluster API objects saved in a directory to a target management cluster.
+	FromDirectory(toCluster Client, directory string, options MoveOptions) (*MoveSummary, error)
+
+	// ToArchive saves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a
+	// gzip-compressed tarball, the same way ToDirectory does, streaming each object into the archive.
+	ToArchive(path string, options MoveOptions) (*MoveSummary, error)
+
+	// FromArchive restores all the Cluster API objects saved in a gzip-compressed tarball to a target management cluster.
+	FromArchive(toCluster Client, path string, options MoveOptions) (*MoveSummary, error)
+
+	// Sync creates or updates all the Cluster API objects existing in a namespace (or from all the namespaces if empty) in a target
+	// management cluster, without pausing or deleting the objects in the source management cluster, e.g. for keeping a warm-standby
+	// management cluster; the Clusters are kept paused in the target management cluster. When running in dry-run mode, toCluster can be nil.
+	Sync(toCluster Client, options MoveOptions) (*MoveSummary, error)
+
+	// SetPaused sets the paused field on all the Clusters existing in a namespace (or in all the namespaces if empty) without
+	// moving any object, e.g. for resuming the reconciliation of the Clusters in the source management cluster after a failed move.
+	SetPaused(paused bool, options MoveOptions) (*MoveSummary, error)
+
+	// ListObjects discovers the Cluster API objects existing in a namespace (or in all the namespaces if empty) that would be
+	// moved, without checking them and without computing the move sequence; the objects are listed in the Discovered field
+	// of the summary.
+	ListObjects(options MoveOptions) (*MoveSummary, error)
+
+	// DiscoverGraph discovers the Cluster API objects existing in a namespace (or in all the namespaces if empty) that would be
+	// moved, returning a snapshot of the objects, of their dependencies and of the order they would be moved in, without
+	// checking them and without making any change.
+	DiscoverGraph(options MoveOptions) (*MoveGraph, error)
 }
 
 // objectMover implements the ObjectMover interface.
 type objectMover struct {
+	fromKubeconfig        Kubeconfig
 	fromProxy             Proxy
 	fromProviderInventory InventoryClient
+	pollImmediateWaiter   PollImmediateWaiter
+	dryRun                bool
+
+	// parallelism is the maximum number of objects in the same moveGroup that are processed concurrently.
+	parallelism int
+
+	// retries and retryBackoff define the exponential backoff used when creating or deleting objects fails with a transient error.
+	retries      int
+	retryBackoff time.Duration
+
+	// deleteTimeout defines how long to wait for the objects deleted from the source management cluster to disappear.
+	deleteTimeout time.Duration
+
+	// skipExisting is set when the objects already existing in the target management cluster must not be overwritten.
+	skipExisting bool
+
+	// copyOnly is set when the objects must be kept in the source management cluster, without pausing them.
+	copyOnly bool
+
+	// managedExternally lists the infrastructure objects managed by an external system, which are left in the source
+	// management cluster and must exist in the target management cluster.
+	managedExternally []*node
+
+	// verifyObjects is set when the objects created in the target management cluster must be read back and compared with
+	// the objects move intended to create.
+	verifyObjects bool
+
+	// preservePaused is set when the Clusters that were already paused before the move must be kept paused.
+	preservePaused bool
+
+	// transformers are applied to each object before creating it in the target management cluster.
+	transformers []ObjectTransformer
+
+	// syncMode is set when the objects in the source management cluster are copied to the target management cluster without
+	// being paused or deleted; in this case, Clusters are kept paused in the target management cluster, and objects already
+	// existing in the target management cluster are updated only if changed.
+	syncMode bool
+
+	// diff is set when sync must only compute how the objects would be changed in the target management cluster.
+	diff bool
+
+	// waitForCompletion is set when the moved Clusters and Machines must be provisioned in the target management cluster
+	// before the move completes, waiting at most waitForCompletionTimeout.
+	waitForCompletion        bool
+	waitForCompletionTimeout time.Duration
+
+	// state records the progress of the move operation, if a state file is used.
+	state *moveState
+
+	// ctx is the context for the current move operation, with a deadline if a timeout is set.
+	ctx     context.Context
+	timeout time.Duration
+
+	// summary reports the outcome of the current operation.
+	summary     MoveSummary
+	summaryLock sync.Mutex
+
+	// continueOnError is set when the objects that can be moved must be moved even if other objects fail.
+	continueOnError bool
+
+	// failedNodes records the objects that failed, or that were skipped because an object they depend on failed.
+	// Nb. Access is serialized using summaryLock.
+	failedNodes map[*node]bool
+
+	// toNamespace is the namespace in the target management cluster where the objects are moved to, if remapped.
+	toNamespace string
+
+	// provenance lists the annotations recording where and when the objects were moved from, to be added to the objects
+	// created in the target management cluster, if required.
+	provenance map[string]string
+
+	// rewriteRefs maps namespaces other than the source namespace to namespaces in the target management cluster, for
+	// remapping the references to objects in those namespaces when objects are moved to toNamespace.
+	rewriteRefs map[string]string
+
+	// rewriteFinalizers maps finalizers of the source objects to the finalizers set on the target objects; finalizers mapped
+	// to an empty string are removed.
+	rewriteFinalizers map[string]string
+
+	// renameClusters maps the names of the source Clusters to the names of the target Clusters, if renamed.
+	renameClusters map[string]string
+
+	// renamedObjects maps the UIDs of the source objects renamed in the target management cluster, i.e. the renamed Clusters
+	// and their Secrets, to the target name, while renamedClusters and renamedSecrets map the namespace/name of the renamed
+	// Clusters and Secrets to the target name.
+	renamedObjects  map[types.UID]string
+	renamedClusters map[types.NamespacedName]string
+	renamedSecrets  map[types.NamespacedName]string
+
+	// targetVersions defines, for the kinds whose version stored in the source management cluster is not served by the target
+	// management cluster, the version the objects are converted to.
+	targetVersions map[schema.GroupKind]string
+
+	// fromDirectory is set when restoring objects previously saved to a directory; in this case, objects are read
+	// from the directory instead of from the source management cluster.
+	fromDirectory string
+
+	// fromArchive is set when restoring objects previously saved to an archive; in this case, objects are read from
+	// it, keyed by the name of the file they were saved to, instead of from the source management cluster.
+	fromArchive map[string]unstructured.Unstructured
 }
 
 // ensure objectMover implements the ObjectMover interface.
 var _ ObjectMover = &objectMover{}
 
-func (o *objectMover) Move(namespace string, toCluster Client) error {
-	log := logf.Log
+func (o *objectMover) Move(toCluster Client, options MoveOptions) (*MoveSummary, error) {
+	// Identifies the source and the target management clusters in the logs, for distinguishing concurrent move operations.
+	logValues := kubeconfigLogValues("From", o.fromKubeconfig)
+	if toCluster != nil {
+		logValues = append(logValues, kubeconfigLogValues("To", toCluster.Kubeconfig())...)
+	}
+	log := logf.Log.WithValues(logValues...)
 	log.Info("Performing move...")
+	o.reset()
+	o.setDryRun(options.DryRun)
+	o.parallelism = options.Parallelism
+	o.retries = options.Retries
+	o.retryBackoff = options.RetryBackoff
+	o.deleteTimeout = options.DeleteTimeout
+	o.skipExisting = options.SkipExisting
+	o.verifyObjects = options.VerifyObjects
+	o.setCopyOnly(options.CopyOnly)
+	o.preservePaused = options.PreservePaused
+	o.transformers = options.Transformers
+	o.waitForCompletion = options.WaitForCompletion
+	o.waitForCompletionTimeout = options.WaitForCompletionTimeout
+	o.continueOnError = options.ContinueOnError
+	o.toNamespace = options.ToNamespace
+	o.rewriteRefs = options.RewriteRefs
+	o.rewriteFinalizers = options.RewriteFinalizers
+	o.renameClusters = options.RenameClusters
+	cancel := o.setTimeout(options.Timeout)
+	defer cancel()
+
+	if err := validateNamespaces(options); err != nil {
+		return nil, err
+	}
+
+	// Objects can be remapped to a target namespace only when moving objects from a single namespace.
+	if o.toNamespace != "" && options.Namespace == "" {
+		return nil, errors.New("the source namespace must be set when moving objects to a different target namespace")
+	}
+	if err := validateRewriteRefs(options); err != nil {
+		return nil, err
+	}
+	if err := validateRewriteFinalizers(options); err != nil {
+		return nil, err
+	}
+	if err := validateRenameClusters(options); err != nil {
+		return nil, err
+	}
+
+	// Records the source management cluster in the provenance annotations, if required.
+	if err := o.setProvenance(options, func() (string, error) {
+		return kubeconfigContextName(o.fromKubeconfig)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Sets up the state file recording the progress of the move operation, if any.
+	if err := o.setState(options); err != nil {
+		return nil, err
+	}
+
+	// checks that all the required providers and CRDs are in place in the target cluster.
+	if toCluster != nil {
+		for _, namespace := range getNamespaces(options) {
+			if err := o.checkTargetProviders(namespace, toCluster.ProviderInventory()); err != nil {
+				return nil, err
+			}
+		}
+		if err := o.checkTargetCRDs(toCluster.Proxy()); err != nil {
+			return nil, err
+		}
+
+		// Waits for the providers in the target cluster to be available before pausing the source objects,
+		// so the target cluster can start reconciling the objects as soon as they are moved.
+		if err := o.waitTargetReady(toCluster, options.TargetReadyTimeout); err != nil {
+			return nil, err
+		}
+	}
+
+	var objectGraph *objectGraph
+	if err := o.runPhase("discovering objects", func() error {
+		var err error
+		objectGraph, err = o.discoverObjectGraph(options)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	// Computes the names of the renamed objects in the target cluster, if any.
+	if err := o.setRenamedObjects(objectGraph); err != nil {
+		return nil, err
+	}
+
+	// Writes the object graph, if required.
+	if options.GraphOutput != "" {
+		if err := writeGraph(objectGraph, options.GraphOutput); err != nil {
+			return nil, err
+		}
+	}
+
+	// Checks that the infrastructure objects managed by an external system, if any, exist in the target cluster, because
+	// the moved objects are going to reference them.
+	if toCluster != nil {
+		if err := o.checkManagedExternally(toCluster.Proxy()); err != nil {
+			return nil, err
+		}
+	}
+
+	// When only validating, stop before making any change.
+	if options.ValidateOnly {
+		o.summary.setObjects(getMoveSequence(objectGraph))
+		log.Info("Preflight checks passed, the objects can be moved to the target cluster", "Objects", o.summary.Total)
+		return &o.summary, nil
+	}
+
+	// In dry-run mode there is no target cluster to move objects to.
+	var toProxy Proxy
+	if toCluster != nil {
+		toProxy = toCluster.Proxy()
+	}
+
+	// Move the objects to the target cluster.
+	if err := o.move(objectGraph, toProxy); err != nil {
+		return o.getFailedSummary(), err
+	}
+
+	return o.getSummary(), nil
+}
+
+func (o *objectMover) ToDirectory(directory string, options MoveOptions) (*MoveSummary, error) {
+	log := logf.Log.WithValues(kubeconfigLogValues("From", o.fromKubeconfig)...)
+	log.Info("Performing move to directory...", "Directory", directory)
+	return o.toBackup(newDirectoryWriter(directory), options)
+}
+
+func (o *objectMover) ToArchive(path string, options MoveOptions) (*MoveSummary, error) {
+	log := logf.Log.WithValues(kubeconfigLogValues("From", o.fromKubeconfig)...)
+	log.Info("Performing move to archive...", "Archive", path)
+	return o.toBackup(newArchiveWriter(path), options)
+}
+
+// toBackup saves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) using the given
+// writer, e.g. to a directory or to an archive.
+func (o *objectMover) toBackup(w objectWriter, options MoveOptions) (*MoveSummary, error) {
+	o.reset()
+	o.setDryRun(options.DryRun)
+	o.parallelism = options.Parallelism
+	o.preservePaused = options.PreservePaused
+	cancel := o.setTimeout(options.Timeout)
+	defer cancel()
+
+	if err := validateNamespaces(options); err != nil {
+		return nil, err
+	}
+
+	var objectGraph *objectGraph
+	if err := o.runPhase("discovering objects", func() error {
+		var err error
+		objectGraph, err = o.discoverObjectGraph(options)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	// Writes the object graph, if required.
+	if options.GraphOutput != "" {
+		if err := writeGraph(objectGraph, options.GraphOutput); err != nil {
+			return nil, err
+		}
+	}
+
+	// Save the objects to the target directory or archive.
+	if err := o.save(objectGraph, w); err != nil {
+		return nil, err
+	}
+
+	return o.getSummary(), nil
+}
+
+func (o *objectMover) FromDirectory(toCluster Client, directory string, options MoveOptions) (*MoveSummary, error) {
+	log := logf.Log.WithValues(kubeconfigLogValues("To", toCluster.Kubeconfig())...)
+	log.Info("Performing move from directory...", "Directory", directory)
+	o.reset()
+	o.fromDirectory = directory
+	return o.fromBackup(toCluster, directory, options, func() ([]unstructured.Unstructured, error) {
+		// Verify the objects saved in the directory were not changed since they were saved, unless explicitly skipped.
+		if !options.SkipVerify {
+			if err := verifyManifest(directory); err != nil {
+				return nil, err
+			}
+		}
+
+		// Read all the objects saved in the directory.
+		return readObjectsFromDirectory(directory)
+	})
+}
+
+func (o *objectMover) FromArchive(toCluster Client, path string, options MoveOptions) (*MoveSummary, error) {
+	log := logf.Log.WithValues(kubeconfigLogValues("To", toCluster.Kubeconfig())...)
+	log.Info("Performing move from archive...", "Archive", path)
+	o.reset()
+	return o.fromBackup(toCluster, path, options, func() ([]unstructured.Unstructured, error) {
+		// Read all the objects saved in the archive, verifying they were not changed since they were saved, unless explicitly skipped.
+		objs, err := readArchive(path, !options.SkipVerify)
+		if err != nil {
+			return nil, err
+		}
+		o.fromArchive = objs
+		return archiveObjects(objs), nil
+	})
+}
+
+// fromBackup restores all the Cluster API objects returned by read, e.g. reading them from a directory or from an archive,
+// to a target management cluster; source identifies the backup in the provenance annotations.
+func (o *objectMover) fromBackup(toCluster Client, source string, options MoveOptions, read func() ([]unstructured.Unstructured, error)) (*MoveSummary, error) {
+	o.setDryRun(options.DryRun)
+	o.parallelism = options.Parallelism
+	o.retries = options.Retries
+	o.retryBackoff = options.RetryBackoff
+	o.skipExisting = options.SkipExisting
+	o.verifyObjects = options.VerifyObjects
+	o.transformers = options.Transformers
+	o.waitForCompletion = options.WaitForCompletion
+	o.waitForCompletionTimeout = options.WaitForCompletionTimeout
+	o.continueOnError = options.ContinueOnError
+	o.toNamespace = options.ToNamespace
+	o.rewriteRefs = options.RewriteRefs
+	o.rewriteFinalizers = options.RewriteFinalizers
+	o.renameClusters = options.RenameClusters
+	cancel := o.setTimeout(options.Timeout)
+	defer cancel()
+
+	if err := validateRewriteRefs(options); err != nil {
+		return nil, err
+	}
+	if err := validateRewriteFinalizers(options); err != nil {
+		return nil, err
+	}
+	if err := validateRenameClusters(options); err != nil {
+		return nil, err
+	}
+
+	// Waits for the providers in the target cluster to be available before restoring the objects,
+	// so the target cluster can start reconciling the objects as soon as they are restored.
+	if !o.dryRun {
+		if err := o.waitTargetReady(toCluster, options.TargetReadyTimeout); err != nil {
+			return nil, err
+		}
+	}
+
+	// Records the source directory or archive in the provenance annotations, if required.
+	if err := o.setProvenance(options, func() (string, error) {
+		return source, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	objs, err := read()
+	if err != nil {
+		return nil, err
+	}
+
+	// Rebuild the object graph from the saved objects; OwnerReferences are saved as they are, so the graph is the same
+	// computed during the discovery phase on the source management cluster.
+	objectGraph := newObjectGraph(nil)
+	if err := objectGraph.addRestoredObjs(objs); err != nil {
+		return nil, err
+	}
+
+	// Restricts the object graph to the selected Clusters or to the shared objects, if required.
+	if err := selectObjects(objectGraph, options); err != nil {
+		return nil, err
+	}
+
+	// Removes the excluded kinds from the object graph, if any.
+	if err := excludeKinds(objectGraph, options); err != nil {
+		return nil, err
+	}
+
+	// Removes the infrastructure objects managed by an external system from the object graph, if required, and checks
+	// they exist in the target management cluster.
+	o.managedExternally = excludeManagedExternally(objectGraph, options)
+	if err := o.checkManagedExternally(toCluster.Proxy()); err != nil {
+		return nil, err
+	}
+
+	// Computes the names of the renamed objects in the target cluster, if any.
+	if err := o.setRenamedObjects(objectGraph); err != nil {
+		return nil, err
+	}
+
+	// Writes the object graph, if required.
+	if options.GraphOutput != "" {
+		if err := writeGraph(objectGraph, options.GraphOutput); err != nil {
+			return nil, err
+		}
+	}
+
+	// Restore the objects to the target cluster.
+	if err := o.restore(objectGraph, toCluster.Proxy()); err != nil {
+		return o.getFailedSummary(), err
+	}
+
+	return o.getSummary(), nil
+}
+
+func (o *objectMover) Sync(toCluster Client, options MoveOptions) (*MoveSummary, error) {
+	logValues := kubeconfigLogValues("From", o.fromKubeconfig)
+	if toCluster != nil {
+		logValues = append(logValues, kubeconfigLogValues("To", toCluster.Kubeconfig())...)
+	}
+	log := logf.Log.WithValues(logValues...)
+	log.Info("Performing sync...")
+	o.reset()
+	o.setDryRun(options.DryRun)
+	o.parallelism = options.Parallelism
+	o.retries = options.Retries
+	o.retryBackoff = options.RetryBackoff
+	o.syncMode = true
+	o.diff = options.Diff
+	o.verifyObjects = options.VerifyObjects
+	o.transformers = options.Transformers
+	o.continueOnError = options.ContinueOnError
+	o.rewriteFinalizers = options.RewriteFinalizers
+	cancel := o.setTimeout(options.Timeout)
+	defer cancel()
+
+	if err := validateNamespaces(options); err != nil {
+		return nil, err
+	}
+	if err := validateRewriteFinalizers(options); err != nil {
+		return nil, err
+	}
+
+	// checks that all the required providers and CRDs are in place in the target cluster.
+	if toCluster != nil {
+		for _, namespace := range getNamespaces(options) {
+			if err := o.checkTargetProviders(namespace, toCluster.ProviderInventory()); err != nil {
+				return nil, err
+			}
+		}
+		if err := o.checkTargetCRDs(toCluster.Proxy()); err != nil {
+			return nil, err
+		}
+		if err := o.waitTargetReady(toCluster, options.TargetReadyTimeout); err != nil {
+			return nil, err
+		}
+	}
 
+	var objectGraph *objectGraph
+	if err := o.runPhase("discovering objects", func() error {
+		var err error
+		objectGraph, err = o.discoverObjectGraph(options)
+		if err != nil {
+			return err
+		}
+		o.recordUnchanged(selectModifiedSince(objectGraph, options.Since))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// In dry-run mode there is no target cluster to sync objects to.
+	var toProxy Proxy
+	if toCluster != nil {
+		toProxy = toCluster.Proxy()
+	}
+
+	// Copy the objects to the target cluster.
+	if err := o.syncObjects(objectGraph, toProxy); err != nil {
+		return o.getFailedSummary(), err
+	}
+
+	return o.getSummary(), nil
+}
+
+func (o *objectMover) SetPaused(paused bool, options MoveOptions) (*MoveSummary, error) {
+	log := logf.Log
+	log.Info("Setting Cluster.Spec.Paused...", "Paused", paused)
+	o.reset()
+	o.setDryRun(options.DryRun)
+	cancel := o.setTimeout(options.Timeout)
+	defer cancel()
+
+	if err := validateNamespaces(options); err != nil {
+		return nil, err
+	}
+
+	// Discovers the Clusters, restricting them to the selected ones, if any.
+	// Nb. Provisioning is not checked, because the Clusters are not going to be moved.
 	objectGraph := newObjectGraph(o.fromProxy)
+	objectGraph.strictDiscovery = options.StrictDiscovery
+	if err := o.runPhase("discovering objects", func() error {
+		types, err := objectGraph.getDiscoveryTypes()
+		if err != nil {
+			return err
+		}
+		if err := objectGraph.DiscoveryInNamespaces(getNamespaces(options), types); err != nil {
+			return err
+		}
+		o.summary.SkippedKinds = objectGraph.skippedKinds
+		return selectClusters(objectGraph, options)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Sets the paused field on the Clusters.
+	if err := o.setPaused(objectGraph, paused); err != nil {
+		return nil, err
+	}
+
+	summary := o.summary
+	return &summary, nil
+}
 
-	// checks that all the required providers in place in the target cluster.
-	if err := o.checkTargetProviders(namespace, toCluster.ProviderInventory()); err != nil {
+func (o *objectMover) ListObjects(options MoveOptions) (*MoveSummary, error) {
+	log := logf.Log
+	log.Info("Listing the objects to be moved...")
+	o.reset()
+	cancel := o.setTimeout(options.Timeout)
+	defer cancel()
+
+	if err := validateNamespaces(options); err != nil {
+		return nil, err
+	}
+
+	// Discovers the objects to be moved.
+	// Nb. Provisioning is not checked and the move sequence is not computed, because the objects are not going to be moved.
+	var objectGraph *objectGraph
+	if err := o.runPhase("discovering objects", func() error {
+		var err error
+		objectGraph, err = o.discoverObjects(options)
 		return err
+	}); err != nil {
+		return nil, err
 	}
 
-	// Gets all the types defines by the CRDs installed by clusterctl plus the ConfigMap/Secret core types.
-	types, err := objectGraph.getDiscoveryTypes()
+	o.listObjects(objectGraph)
+
+	summary := o.summary
+	return &summary, nil
+}
+
+func (o *objectMover) DiscoverGraph(options MoveOptions) (*MoveGraph, error) {
+	log := logf.Log
+	log.Info("Discovering the objects to be moved...")
+	o.reset()
+	cancel := o.setTimeout(options.Timeout)
+	defer cancel()
+
+	if err := validateNamespaces(options); err != nil {
+		return nil, err
+	}
+
+	// Nb. Provisioning is not checked, because the objects are not going to be moved.
+	objectGraph, err := o.discoverObjects(options)
 	if err != nil {
+		return nil, err
+	}
+	return newMoveGraph(objectGraph), nil
+}
+
+// listObjects records in the summary the objects in the object graph that are going to be moved, i.e. the objects belonging
+// to a Cluster, sorted by kind, namespace and name.
+// Nb. The owners referenced by the objects but not found during the discovery are skipped, because they are not going to be moved.
+func (o *objectMover) listObjects(graph *objectGraph) {
+	nodes := graph.getNodesWithClusterTenants()
+	sortNodes(nodes)
+	o.summary.Objects = map[string]int{}
+	for _, n := range nodes {
+		if n.virtual {
+			continue
+		}
+		o.summary.Total++
+		o.summary.Objects[n.identity.Kind]++
+		o.recordObject(&o.summary.Discovered, n, nil)
+	}
+}
+
+// setPaused sets the paused field on all the Clusters in the object graph.
+func (o *objectMover) setPaused(graph *objectGraph, paused bool) error {
+	log := logf.Log
+
+	clusters := graph.getClusters()
+	o.summary.Total = len(clusters)
+	o.summary.Objects = map[string]int{"Cluster": len(clusters)}
+
+	// In dry-run mode, print the Clusters and stop before making any change.
+	if o.dryRun {
+		for _, cluster := range clusters {
+			log.Info("Would set Cluster.Spec.Paused", "Paused", paused, "Cluster", cluster.identity.Name, "Namespace", cluster.identity.Namespace)
+		}
+		return nil
+	}
+
+	phase := "resuming the source cluster"
+	if paused {
+		phase = "pausing the source cluster"
+	}
+	if err := o.runPhase(phase, func() error {
+		return setClusterPause(o.getContext(), o.fromProxy, clusters, paused)
+	}); err != nil {
 		return err
 	}
 
-	// Discovery the object graph for the selected types:
-	// - Nodes are defined the Kubernetes objects (Clusters, Machines etc.) identified during the discovery process.
-	// - Edges are derived by the OwnerReferences between nodes.
-	if err := objectGraph.Discovery(namespace, types); err != nil {
+	log.Info("Set Cluster.Spec.Paused completed", "Paused", paused, "Clusters", len(clusters))
+	return nil
+}
+
+// getSummary returns the summary of the current operation, logging it unless running in dry-run mode.
+func (o *objectMover) getSummary() *MoveSummary {
+	summary := o.summary
+	if !o.dryRun {
+		logSummary(&summary)
+	}
+	return &summary
+}
+
+// getFailedSummary returns a copy of the summary of a failed operation, reporting the objects processed before the failure;
+// unlike getSummary, the summary is not logged.
+func (o *objectMover) getFailedSummary() *MoveSummary {
+	summary := o.summary
+	return &summary
+}
+
+// setDryRun sets the dry-run mode for the current operation, informing the user when it is enabled.
+func (o *objectMover) setDryRun(dryRun bool) {
+	o.dryRun = dryRun
+	if o.dryRun {
+		log := logf.Log
+		log.Info("********************************************************")
+		log.Info("This is a dry-run move, will not perform any real action")
+		log.Info("********************************************************")
+	}
+}
+
+// reset clears the options and the results of the previous operation, so an objectMover can be reused for running
+// operations of any kind, e.g. a sync followed by a move, each one depending only on the options it is called with.
+func (o *objectMover) reset() {
+	*o = objectMover{
+		fromKubeconfig:        o.fromKubeconfig,
+		fromProxy:             o.fromProxy,
+		fromProviderInventory: o.fromProviderInventory,
+		pollImmediateWaiter:   o.pollImmediateWaiter,
+	}
+}
+
+// setTimeout sets the context for the current operation, with a deadline if a timeout is defined;
+// the returned function must be called to release the context resources once the operation completes.
+func (o *objectMover) setTimeout(timeout time.Duration) context.CancelFunc {
+	o.timeout = timeout
+	if timeout <= 0 {
+		o.ctx = ctx
+		return func() {}
+	}
+
+	var cancel context.CancelFunc
+	o.ctx, cancel = context.WithTimeout(ctx, timeout)
+	return cancel
+}
+
+// setCopyOnly sets copy mode, printing a warning because both the source and the target management cluster are going to
+// reconcile the same infrastructure.
+func (o *objectMover) setCopyOnly(copyOnly bool) {
+	o.copyOnly = copyOnly
+	if o.copyOnly {
+		log := logf.Log
+		log.Info("*****************************************************************************************")
+		log.Info("WARNING: copying objects; the source objects are not paused nor deleted, so both the")
+		log.Info("source and the target cluster are going to reconcile the same infrastructure, unless the")
+		log.Info("objects are transformed to use different credentials")
+		log.Info("*****************************************************************************************")
+	}
+}
+
+// newRetryBackoff returns the exponential backoff used when creating or deleting objects fails with a transient error.
+func (o *objectMover) newRetryBackoff() wait.Backoff {
+	backoff := newBackoff()
+	backoff.Steps = 1
+	if o.retries > 0 {
+		backoff.Steps = o.retries + 1
+	}
+	if o.retryBackoff > 0 {
+		backoff.Duration = o.retryBackoff
+	}
+	return backoff
+}
+
+// isTransientError returns true if an error is likely to be solved by retrying the same operation, e.g. a server timeout,
+// throttling by the API server or a network error.
+func isTransientError(err error) bool {
+	err = errors.Cause(err)
+	if apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) {
+		return true
+	}
+	if utilnet.IsConnectionReset(err) || utilnet.IsConnectionRefused(err) || utilnet.IsProbableEOF(err) {
+		return true
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return true
+	}
+	return false
+}
+
+// getContext returns the context for the API calls of the current operation.
+func (o *objectMover) getContext() context.Context {
+	if o.ctx == nil {
+		return ctx
+	}
+	return o.ctx
+}
+
+// wrapTimeout adds to an error the phase of the move operation that was in progress when the timeout expired, if this is the case.
+// Nb. Errors related to a specific object already include the object kind, namespace and name.
+func (o *objectMover) wrapTimeout(err error, phase string) error {
+	if err == nil || o.getContext().Err() != context.DeadlineExceeded {
 		return err
 	}
+	return errors.Wrapf(err, "move timed out after %s while %s", o.timeout, phase)
+}
+
+// setState sets up the state file recording the progress of the move operation; when resuming an interrupted move,
+// the progress recorded in the state file is read back.
+func (o *objectMover) setState(options MoveOptions) error {
+	o.state = nil
+	if options.StateFile == "" {
+		if options.Resume {
+			return errors.New("a state file is required for resuming an interrupted move")
+		}
+		return nil
+	}
+
+	if options.Resume {
+		state, err := readMoveState(options.StateFile)
+		if err != nil {
+			return err
+		}
+		log := logf.Log
+		log.Info("Resuming an interrupted move", "StateFile", options.StateFile, "Created", len(state.Created), "Deleted", len(state.Deleted))
+		o.state = state
+		return nil
+	}
+
+	// In dry-run mode, or when only validating, there is no progress to be recorded.
+	if o.dryRun || options.ValidateOnly {
+		return nil
+	}
+
+	state, err := newMoveState(options.StateFile)
+	if err != nil {
+		return err
+	}
+	o.state = state
+	return nil
+}
+
+// discoverObjectGraph discovers the graph of the Cluster API objects existing in a namespace (or in all namespaces if empty),
+// and checks that all the objects are in a state that allows the move operation.
+func (o *objectMover) discoverObjectGraph(options MoveOptions) (*objectGraph, error) {
+	objectGraph, err := o.discoverObjects(options)
+	if err != nil {
+		return nil, err
+	}
 
 	// Checks if Cluster API has already completed the provisioning of the infrastructure for the objects involved in the move operation.
 	// This is required because if the infrastructure is provisioned, then we can reasonably assume that the objects we are moving are
 	// not currently waiting for long-running reconciliation loops, and so we can safely rely on the pause field on the Cluster object
 	// for blocking any further object reconciliation on the source objects.
 	if err := o.checkProvisioningCompleted(objectGraph); err != nil {
-		return err
+		return nil, err
 	}
 	//TODO: consider if to add additional preflight checks ensuring the object graph is complete (no virtual nodes left)
 
-	// Move the objects to the target cluster.
-	if err := o.move(objectGraph, toCluster.Proxy()); err != nil {
+	return objectGraph, nil
+}
+
+// discoverObjects discovers the graph of the Cluster API objects existing in a namespace (or in all namespaces if empty),
+// restricted to the objects selected by the move options.
+func (o *objectMover) discoverObjects(options MoveOptions) (*objectGraph, error) {
+	objectGraph := newObjectGraph(o.fromProxy)
+	objectGraph.strictDiscovery = options.StrictDiscovery
+
+	// Gets all the types defines by the CRDs installed by clusterctl plus the ConfigMap/Secret core types.
+	types, err := objectGraph.getDiscoveryTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	// Discovery the object graph for the selected types:
+	// - Nodes are defined the Kubernetes objects (Clusters, Machines etc.) identified during the discovery process.
+	// - Edges are derived by the OwnerReferences between nodes.
+	if err := objectGraph.DiscoveryInNamespaces(getNamespaces(options), types); err != nil {
+		return nil, err
+	}
+	o.summary.SkippedKinds = objectGraph.skippedKinds
+
+	// Restricts the object graph to the selected Clusters or to the shared objects, if required.
+	if err := selectObjects(objectGraph, options); err != nil {
+		return nil, err
+	}
+
+	// Removes the excluded kinds from the object graph, if any.
+	if err := excludeKinds(objectGraph, options); err != nil {
+		return nil, err
+	}
+
+	// Removes the infrastructure objects managed by an external system from the object graph, if required.
+	o.managedExternally = excludeManagedExternally(objectGraph, options)
+
+	return objectGraph, nil
+}
+
+// selectObjects restricts the object graph to the objects selected by the move options, if any.
+func selectObjects(graph *objectGraph, options MoveOptions) error {
+	if options.SharedOnly {
+		if options.ClusterName != "" || options.LabelSelector != "" {
+			return errors.New("the cluster name and the label selector can't be used when moving only the shared objects")
+		}
+		if options.RootKind != "" {
+			return errors.New("the root kind can't be used when moving only the shared objects")
+		}
+		graph.filterShared()
+		return nil
+	}
+	if err := selectClusters(graph, options); err != nil {
+		return err
+	}
+	return selectRootKind(graph, options)
+}
+
+// selectClusters restricts the object graph to the Clusters selected by the move options, if any, and to their dependents.
+func selectClusters(graph *objectGraph, options MoveOptions) error {
+	if options.ClusterName == "" && options.LabelSelector == "" {
+		return nil
+	}
+
+	selector, err := labels.Parse(options.LabelSelector)
+	if err != nil {
+		return errors.Wrapf(err, "invalid label selector %q", options.LabelSelector)
+	}
+
+	isSelected := func(cluster *node) bool {
+		if options.ClusterName != "" && cluster.identity.Name != options.ClusterName {
+			return false
+		}
+		return selector.Matches(labels.Set(cluster.labels))
+	}
+
+	found := false
+	for _, cluster := range graph.getClusters() {
+		if isSelected(cluster) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		if options.LabelSelector == "" {
+			return errors.Errorf("Cluster %q not found", options.ClusterName)
+		}
+		if options.ClusterName == "" {
+			return errors.Errorf("no Clusters matching the label selector %q found", options.LabelSelector)
+		}
+		return errors.Errorf("Cluster %q matching the label selector %q not found", options.ClusterName, options.LabelSelector)
+	}
+
+	return graph.filterClusters(isSelected)
+}
+
+// selectRootKind restricts the object graph to the objects of the root kind defined by the move options, if any, and to their
+// dependents. The Clusters and the other objects the root objects depend on are left in the source management cluster, so a
+// warning is logged for each reference that is going to be dangling in the target management cluster; the Clusters owning the
+// root objects must be paused, so the source controllers do not act on the objects while they are moved.
+func selectRootKind(graph *objectGraph, options MoveOptions) error {
+	if options.RootKind == "" {
+		return nil
+	}
+
+	rootKind, err := parseGroupKind(options.RootKind)
+	if err != nil {
 		return err
 	}
+	if rootKind == clusterv1.GroupVersion.WithKind("Cluster").GroupKind() {
+		return errors.New("Clusters are already the root of move; use the cluster name or a label selector to restrict the Clusters to be moved")
+	}
+
+	isRoot := func(n *node) bool {
+		return hasGroupKind(n, rootKind)
+	}
+
+	roots := 0
+	notPaused := map[string]empty{}
+	for _, n := range graph.getNodes() {
+		if n.virtual || !isRoot(n) {
+			continue
+		}
+		roots++
+		for cluster := range n.tenantClusters {
+			if !cluster.paused {
+				notPaused[nodeRef(cluster)] = empty{}
+			}
+		}
+	}
+	if roots == 0 {
+		return errors.Errorf("no objects of kind %q found", options.RootKind)
+	}
+	if len(notPaused) > 0 {
+		clusters := make([]string, 0, len(notPaused))
+		for cluster := range notPaused {
+			clusters = append(clusters, cluster)
+		}
+		sort.Strings(clusters)
+		return errors.Errorf("the Clusters owning the objects of kind %q must be paused before moving them: %s", options.RootKind, strings.Join(clusters, ", "))
+	}
 
+	log := logf.Log
+	log.Info("WARNING: moving only the objects of the root kind and their dependents; the Clusters and all the other objects they "+
+		"depend on are left in the source management cluster, so they are going to be missing in the target management cluster",
+		"RootKind", options.RootKind, "Objects", roots)
+	for excluded, dependents := range graph.filterRoots(isRoot) {
+		for _, dependent := range dependents {
+			log.Info("Warning: object left in the source management cluster is referenced by an object that is going to be moved",
+				"Excluded", nodeRef(excluded), dependent.identity.Kind, dependent.identity.Name, "Namespace", dependent.identity.Namespace)
+		}
+	}
 	return nil
 }
 
-func newObjectMover(fromProxy Proxy, fromProviderInventory InventoryClient) *objectMover {
+// hasGroupKind returns true if the object corresponding to the node is of the given kind; the kind is matched case-insensitively.
+func hasGroupKind(n *node, gk schema.GroupKind) bool {
+	nodeGK := n.identity.GroupVersionKind().GroupKind()
+	return nodeGK.Group == gk.Group && strings.EqualFold(nodeGK.Kind, gk.Kind)
+}
+
+// selectModifiedSince restricts the object graph to the objects modified after the given time, if any, and to the objects they
+// depend on through the chain of their owners and soft owners, e.g. the unchanged Cluster of a modified Machine, so the references
+// of the modified objects are satisfied in the target management cluster. Objects without managedFields are considered modified.
+// The number of the objects skipped because not modified is returned.
+func selectModifiedSince(graph *objectGraph, since time.Time) int {
+	if since.IsZero() {
+		return 0
+	}
+
+	selected := map[*node]empty{}
+	var selectWithOwners func(n *node)
+	selectWithOwners = func(n *node) {
+		if _, ok := selected[n]; ok {
+			return
+		}
+		selected[n] = empty{}
+		for owner := range n.owners {
+			selectWithOwners(owner)
+		}
+		for owner := range n.softOwners {
+			selectWithOwners(owner)
+		}
+	}
+	for _, n := range graph.getNodes() {
+		if n.lastModified.IsZero() || n.lastModified.After(since) {
+			selectWithOwners(n)
+		}
+	}
+
+	// Nb. Only the objects that would have been synced are counted as skipped, i.e. not the virtual nodes nor the objects
+	// not belonging to any Cluster.
+	unchanged := 0
+	for _, n := range graph.getNodesWithClusterTenants() {
+		if _, ok := selected[n]; !ok && !n.virtual {
+			unchanged++
+		}
+	}
+	graph.excludeNodes(func(n *node) bool {
+		_, ok := selected[n]
+		return !ok
+	})
+
+	log := logf.Log
+	log.Info("Skipping the objects not modified since the given time", "Since", since.Format(time.RFC3339), "Objects", unchanged)
+	return unchanged
+}
+
+// excludeKinds removes the objects of the kinds excluded by the move options, if any, from the object graph.
+// Objects depending on an excluded object are still moved, but a warning is logged because they are going to
+// reference an object that does not exist in the target management cluster.
+func excludeKinds(graph *objectGraph, options MoveOptions) error {
+	if len(options.ExcludeKinds) == 0 {
+		return nil
+	}
+
+	excludedKinds := []schema.GroupKind{}
+	for _, s := range options.ExcludeKinds {
+		gk, err := parseGroupKind(s)
+		if err != nil {
+			return err
+		}
+		if gk == clusterv1.GroupVersion.WithKind("Cluster").GroupKind() {
+			return errors.New("Clusters cannot be excluded from move; use the cluster name or a label selector to restrict the Clusters to be moved")
+		}
+		excludedKinds = append(excludedKinds, gk)
+	}
+
+	isExcluded := func(n *node) bool {
+		for _, excluded := range excludedKinds {
+			if hasGroupKind(n, excluded) {
+				return true
+			}
+		}
+		return false
+	}
+
+	log := logf.Log
+	for excluded, dependents := range graph.excludeNodes(isExcluded) {
+		for _, dependent := range dependents {
+			log.Info("Warning: excluded object is referenced by an object that is going to be moved",
+				"Excluded", fmt.Sprintf("%s %s/%s", excluded.identity.Kind, excluded.identity.Namespace, excluded.identity.Name),
+				dependent.identity.Kind, dependent.identity.Name, "Namespace", dependent.identity.Namespace)
+		}
+	}
+	return nil
+}
+
+// excludeManagedExternally removes from the object graph the infrastructure objects managed by an external system, i.e. with
+// the cluster.x-k8s.io/managed-by annotation, if required by the move options, and returns them. The objects referencing them,
+// e.g. the Clusters, are still moved, and are expected to find the externally managed objects in the target management cluster.
+func excludeManagedExternally(graph *objectGraph, options MoveOptions) []*node {
+	if !options.ExternalInfrastructure {
+		return nil
+	}
+
+	isManagedExternally := func(n *node) bool {
+		return n.managedExternally && !hasGroupKind(n, clusterv1.GroupVersion.WithKind("Cluster").GroupKind())
+	}
+
+	excluded := []*node{}
+	for _, n := range graph.getNodes() {
+		if isManagedExternally(n) {
+			excluded = append(excluded, n)
+		}
+	}
+	sortNodes(excluded)
+
+	log := logf.Log
+	for _, n := range excluded {
+		log.Info("Leaving the externally managed object in the source cluster", n.identity.Kind, n.identity.Name, "Namespace", n.identity.Namespace)
+	}
+	graph.excludeNodes(isManagedExternally)
+	return excluded
+}
+
+// checkManagedExternally checks that the infrastructure objects managed by an external system, which are left in the source
+// management cluster, exist in the target management cluster, so the moved objects can reference them.
+func (o *objectMover) checkManagedExternally(toProxy Proxy) error {
+	if len(o.managedExternally) == 0 {
+		return nil
+	}
+
+	cTo, err := toProxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	errList := []error{}
+	for _, n := range o.managedExternally {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(o.targetAPIVersion(n.identity))
+		obj.SetKind(n.identity.Kind)
+		key := client.ObjectKey{Namespace: o.targetNamespace(n.identity.Namespace), Name: n.identity.Name}
+		if err := cTo.Get(o.getContext(), key, obj); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "error reading %q %s/%s", obj.GroupVersionKind(), key.Namespace, key.Name)
+			}
+			errList = append(errList, errors.Errorf("externally managed %q %s/%s not found in the target cluster", obj.GroupVersionKind(), key.Namespace, key.Name))
+		}
+	}
+	return kerrors.NewAggregate(errList)
+}
+
+// parseGroupKind parses a kind in the group/kind format; if the group is omitted, the core group is assumed.
+func parseGroupKind(s string) (schema.GroupKind, error) {
+	gk := schema.GroupKind{Kind: s}
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		gk.Group = s[:i]
+		gk.Kind = s[i+1:]
+	}
+	if gk.Kind == "" {
+		return schema.GroupKind{}, errors.Errorf("invalid kind %q, the group/kind format is expected", s)
+	}
+	return gk, nil
+}
+
+func newObjectMover(fromKubeconfig Kubeconfig, fromProxy Proxy, fromProviderInventory InventoryClient, pollImmediateWaiter PollImmediateWaiter) *objectMover {
 	return &objectMover{
+		fromKubeconfig:        fromKubeconfig,
 		fromProxy:             fromProxy,
 		fromProviderInventory: fromProviderInventory,
+		pollImmediateWaiter:   pollImmediateWaiter,
 	}
 }
 
@@ -112,7 +1407,7 @@ func (o *objectMover) checkProvisioningCompleted(graph *objectGraph) error {
 			Name:      cluster.identity.Name,
 		}
 
-		if err := cFrom.Get(ctx, clusterObjKey, clusterObj); err != nil {
+		if err := cFrom.Get(o.getContext(), clusterObjKey, clusterObj); err != nil {
 			return errors.Wrapf(err, "error reading %q %s/%s",
 				clusterObj.GroupVersionKind(), clusterObj.GetNamespace(), clusterObj.GetName())
 		}
@@ -142,7 +1437,7 @@ func (o *objectMover) checkProvisioningCompleted(graph *objectGraph) error {
 			Name:      machine.identity.Name,
 		}
 
-		if err := cFrom.Get(ctx, machineObjKey, machineObj); err != nil {
+		if err := cFrom.Get(o.getContext(), machineObjKey, machineObj); err != nil {
 			return errors.Wrapf(err, "error reading %q %s/%s",
 				machineObj.GroupVersionKind(), machineObj.GetNamespace(), machineObj.GetName())
 		}
@@ -162,48 +1457,311 @@ func (o *objectMover) move(graph *objectGraph, toProxy Proxy) error {
 	clusters := graph.getClusters()
 	log.Info("Moving Cluster API objects", "Clusters", len(clusters))
 
-	// Sets the pause field on the Cluster object in the source management cluster, so the controllers stop reconciling it.
-	log.V(1).Info("Pausing the source cluster")
-	if err := setClusterPause(o.fromProxy, clusters, true); err != nil {
-		return err
-	}
-
-	// Ensure all the expected target namespaces are in place before creating objects.
-	log.V(1).Info("Creating target namespaces, if missing")
-	if err := o.ensureNamespaces(graph, toProxy); err != nil {
-		return err
-	}
-
 	// Define the move sequence by processing the ownerReference chain, so we ensure that a Kubernetes object is moved only after its owners.
 	// The sequence is bases on object graph nodes, each one representing a Kubernetes object; nodes are grouped, so bulk of nodes can be moved in parallel. e.g.
 	// - All the Clusters should be moved first (group 1, processed in parallel)
 	// - All the MachineDeployments should be moved second (group 1, processed in parallel)
 	// - then all the MachineSets, then all the Machines, etc.
 	moveSequence := getMoveSequence(graph)
+	o.summary.setObjects(moveSequence)
+
+	// In dry-run mode, print the move sequence and stop before making any change.
+	if o.dryRun {
+		printMoveSequence(moveSequence)
+		return nil
+	}
+
+	// Informs the users watching the events in the source management cluster that the Clusters are being moved, or copied.
+	startedReason, completedReason := eventReasonMoving, eventReasonMoved
+	if o.copyOnly {
+		startedReason, completedReason = eventReasonCopying, eventReasonCopied
+	}
+	recordClusterEvents(o.getContext(), o.fromProxy, clusters, startedReason, startedReason+" the Cluster to another management cluster")
+
+	// Records which Clusters were already paused before pausing them, so an interrupted move can preserve their paused state
+	// once resumed.
+	if o.state != nil {
+		if err := o.state.syncPaused(clusters); err != nil {
+			return err
+		}
+	}
+
+	// Nb. When copying, the objects in the source management cluster are not paused nor deleted.
+	if !o.copyOnly {
+		// Sets the pause field on the Cluster object in the source management cluster, so the controllers stop reconciling it.
+		log.V(1).Info("Pausing the source cluster")
+		if err := o.runPhase("pausing the source cluster", func() error {
+			return setClusterPause(o.getContext(), o.fromProxy, clusters, true)
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Ensure all the expected target namespaces are in place before creating objects.
+	log.V(1).Info("Creating target namespaces, if missing")
+	if err := o.runPhase("creating target namespaces", func() error {
+		return o.ensureNamespaces(graph, toProxy)
+	}); err != nil {
+		return err
+	}
 
 	// Create all objects group by group, ensuring all the ownerReferences are re-created.
 	log.Info("Creating objects in the target cluster")
-	for groupIndex := 0; groupIndex < len(moveSequence.groups); groupIndex++ {
-		if err := o.createGroup(moveSequence.getGroup(groupIndex), toProxy); err != nil {
+	errList := []error{}
+	if err := o.runPhase("creating objects in the target cluster", func() error {
+		for groupIndex := 0; groupIndex < len(moveSequence.groups); groupIndex++ {
+			logGroupProgress("Creating", groupIndex, len(moveSequence.groups), moveSequence.getGroup(groupIndex))
+			if err := o.createGroup(moveSequence.getGroup(groupIndex), toProxy); err != nil {
+				if !o.canContinue() {
+					return err
+				}
+				errList = append(errList, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Informs the users watching the events in both management clusters that the Clusters are moved, or copied.
+	// Nb. When continuing on error, no event is recorded for the Clusters with failed objects.
+	completedClusters := o.getCompletedClusters(clusters)
+	recordClusterEvents(o.getContext(), o.fromProxy, completedClusters, completedReason, completedReason+" the Cluster to another management cluster")
+	recordClusterEvents(o.getContext(), toProxy, o.toTargetNodes(completedClusters), completedReason, completedReason+" the Cluster from another management cluster")
+
+	if !o.copyOnly {
+		// Delete all objects group by group in reverse order.
+		log.Info("Deleting objects from the source cluster")
+		// Nb. When continuing on error, the objects belonging to Clusters with failed objects are kept in the source cluster.
+		if err := o.runPhase("deleting objects from the source cluster", func() error {
+			for groupIndex := len(moveSequence.groups) - 1; groupIndex >= 0; groupIndex-- {
+				logGroupProgress("Deleting", len(moveSequence.groups)-1-groupIndex, len(moveSequence.groups), moveSequence.getGroup(groupIndex))
+				if err := o.deleteGroup(moveSequence.getGroup(groupIndex)); err != nil {
+					if !o.canContinue() {
+						return err
+					}
+					errList = append(errList, err)
+				}
+			}
+			return nil
+		}); err != nil {
 			return err
 		}
+
+		// Wait for all the objects to actually disappear from the source cluster, so the move does not report success while
+		// e.g. a finalizer added back by a controller is blocking the deletion.
+		log.Info("Waiting for objects to be deleted from the source cluster")
+		if err := o.runPhase("waiting for objects to be deleted from the source cluster", func() error {
+			return o.waitSourceDeleted(moveSequence)
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Reset the pause field on the Cluster object in the target management cluster, so the controllers start reconciling it.
+	log.V(1).Info("Resuming the target cluster")
+	// Nb. When continuing on error, Clusters with failed objects are left paused in both the source and the target cluster.
+	if err := o.runPhase("resuming the target cluster", func() error {
+		return setClusterPause(o.getContext(), toProxy, o.toTargetNodes(o.getClustersToResume(o.getCompletedClusters(clusters))), false)
+	}); err != nil {
+		return err
 	}
 
-	// Delete all objects group by group in reverse order.
-	log.Info("Deleting objects from the source cluster")
-	for groupIndex := len(moveSequence.groups) - 1; groupIndex >= 0; groupIndex-- {
-		if err := o.deleteGroup(moveSequence.getGroup(groupIndex)); err != nil {
+	// If some objects failed, report all the errors; the progress is kept, so the move can be resumed once the errors are fixed.
+	if len(errList) > 0 {
+		return kerrors.NewAggregate(errList)
+	}
+
+	// The move operation is completed, so there is no more progress to be recorded.
+	if o.state != nil {
+		if err := o.state.remove(); err != nil {
 			return err
 		}
 	}
 
+	// Wait for the moved Clusters and Machines to be provisioned again in the target management cluster, if required.
+	return o.waitForTargetProvisioned(graph, toProxy)
+}
+
+// syncObjects creates or updates all the Cluster API objects in the target management cluster, without pausing or deleting
+// them in the source management cluster.
+func (o *objectMover) syncObjects(graph *objectGraph, toProxy Proxy) error {
+	log := logf.Log
+
+	clusters := graph.getClusters()
+	log.Info("Syncing Cluster API objects", "Clusters", len(clusters))
+
+	// Define the move sequence by processing the ownerReference chain, so objects are created only after their owners.
+	moveSequence := getMoveSequence(graph)
+	o.summary.setObjects(moveSequence)
+
+	// In dry-run mode, print the move sequence and stop before making any change.
+	if o.dryRun {
+		printMoveSequence(moveSequence)
+		return nil
+	}
+
+	// In diff mode, compare the objects with the target cluster and stop before making any change.
+	if o.diff {
+		return o.runPhase("computing the diff with the target cluster", func() error {
+			return o.diffObjects(moveSequence, toProxy)
+		})
+	}
+
+	// Ensure all the expected target namespaces are in place before creating objects.
+	log.V(1).Info("Creating target namespaces, if missing")
+	if err := o.runPhase("creating target namespaces", func() error {
+		return o.ensureNamespaces(graph, toProxy)
+	}); err != nil {
+		return err
+	}
+
+	// Create or update all objects group by group, ensuring all the ownerReferences are re-created.
+	// Nb. Clusters are created paused, so the target cluster does not reconcile them while the source cluster is still active.
+	log.Info("Syncing objects to the target cluster")
+	errList := []error{}
+	if err := o.runPhase("syncing objects to the target cluster", func() error {
+		for groupIndex := 0; groupIndex < len(moveSequence.groups); groupIndex++ {
+			logGroupProgress("Syncing", groupIndex, len(moveSequence.groups), moveSequence.getGroup(groupIndex))
+			if err := o.createGroup(moveSequence.getGroup(groupIndex), toProxy); err != nil {
+				if !o.canContinue() {
+					return err
+				}
+				errList = append(errList, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return kerrors.NewAggregate(errList)
+}
+
+// save saves all the Cluster API objects in the object graph using the given writer, e.g. to a target directory or archive.
+func (o *objectMover) save(graph *objectGraph, w objectWriter) error {
+	log := logf.Log
+
+	clusters := graph.getClusters()
+	log.Info("Saving Cluster API objects", "Clusters", len(clusters))
+
+	// Define the move sequence by processing the ownerReference chain, so objects are saved in the same order they are moved.
+	moveSequence := getMoveSequence(graph)
+	o.summary.setObjects(moveSequence)
+
+	// In dry-run mode, print the move sequence and stop before making any change.
+	if o.dryRun {
+		printMoveSequence(moveSequence)
+		return nil
+	}
+
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	// Sets the pause field on the Cluster object in the source management cluster, so the controllers stop reconciling it
+	// while the objects are saved.
+	log.V(1).Info("Pausing the source cluster")
+	if err := o.runPhase("pausing the source cluster", func() error {
+		return setClusterPause(o.getContext(), o.fromProxy, clusters, true)
+	}); err != nil {
+		return err
+	}
+
+	// Save all objects group by group.
+	log.Info("Saving objects")
+	saveErr := o.runPhase("saving objects", func() error {
+		for groupIndex := 0; groupIndex < len(moveSequence.groups); groupIndex++ {
+			logGroupProgress("Saving", groupIndex, len(moveSequence.groups), moveSequence.getGroup(groupIndex))
+			if err := o.saveGroup(moveSequence.getGroup(groupIndex), w); err != nil {
+				return err
+			}
+		}
+
+		// Write the manifest listing the checksum of all the saved files, so the backup can be verified before restoring it.
+		fileNames := []string{}
+		for _, group := range moveSequence.groups {
+			for _, n := range group {
+				fileNames = append(fileNames, n.fileName())
+			}
+		}
+		return w.writeManifest(fileNames, o.fromKubeconfig)
+	})
+
+	// Completes writing the saved objects, e.g. flushing the archive.
+	if err := w.close(); err != nil && saveErr == nil {
+		saveErr = err
+	}
+
+	// Reset the pause field on the Cluster object in the source management cluster, so the controllers start reconciling it again.
+	// Nb. This happens also if saving objects failed or the move timed out, so the source cluster is not left paused.
+	log.V(1).Info("Resuming the source cluster")
+	if err := setClusterPause(ctx, o.fromProxy, o.getClustersToResume(clusters), false); err != nil {
+		return kerrors.NewAggregate([]error{saveErr, err})
+	}
+
+	return saveErr
+}
+
+// restore creates all the Cluster API objects read from a directory into a target management cluster.
+func (o *objectMover) restore(graph *objectGraph, toProxy Proxy) error {
+	log := logf.Log
+
+	clusters := graph.getClusters()
+	log.Info("Restoring Cluster API objects", "Clusters", len(clusters))
+
+	// Define the move sequence by processing the ownerReference chain, so we ensure that a Kubernetes object is restored only after its owners.
+	moveSequence := getMoveSequence(graph)
+	o.summary.setObjects(moveSequence)
+
+	// In dry-run mode, print the move sequence and stop before making any change.
+	if o.dryRun {
+		printMoveSequence(moveSequence)
+		return nil
+	}
+
+	// Ensure all the expected target namespaces are in place before creating objects.
+	log.V(1).Info("Creating target namespaces, if missing")
+	if err := o.runPhase("creating target namespaces", func() error {
+		return o.ensureNamespaces(graph, toProxy)
+	}); err != nil {
+		return err
+	}
+
+	// Create all objects group by group, ensuring all the ownerReferences are re-created.
+	// Nb. Clusters were saved while paused, so they are created paused, the same way they are during move.
+	log.Info("Creating objects in the target cluster")
+	errList := []error{}
+	if err := o.runPhase("creating objects in the target cluster", func() error {
+		for groupIndex := 0; groupIndex < len(moveSequence.groups); groupIndex++ {
+			logGroupProgress("Creating", groupIndex, len(moveSequence.groups), moveSequence.getGroup(groupIndex))
+			if err := o.createGroup(moveSequence.getGroup(groupIndex), toProxy); err != nil {
+				if !o.canContinue() {
+					return err
+				}
+				errList = append(errList, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
 	// Reset the pause field on the Cluster object in the target management cluster, so the controllers start reconciling it.
 	log.V(1).Info("Resuming the target cluster")
-	if err := setClusterPause(toProxy, clusters, false); err != nil {
+	// Nb. When continuing on error, Clusters with failed objects are left paused in both the source and the target cluster.
+	if err := o.runPhase("resuming the target cluster", func() error {
+		return setClusterPause(o.getContext(), toProxy, o.toTargetNodes(o.getCompletedClusters(clusters)), false)
+	}); err != nil {
 		return err
 	}
 
-	return nil
+	if len(errList) > 0 {
+		return kerrors.NewAggregate(errList)
+	}
+
+	// Wait for the restored Clusters and Machines to be provisioned again in the target management cluster, if required.
+	return o.waitForTargetProvisioned(graph, toProxy)
 }
 
 // moveSequence defines a list of group of moveGroups
@@ -280,8 +1838,25 @@ func getMoveSequence(graph *objectGraph) *moveSequence {
 	return moveSequence
 }
 
+// printMoveSequence logs all the objects in a move sequence, group by group, in the order they are going to be moved.
+func printMoveSequence(moveSequence *moveSequence) {
+	log := logf.Log
+	for groupIndex := range moveSequence.groups {
+		group := moveSequence.getGroup(groupIndex)
+
+		// Sort nodes within a group so the output is stable across runs.
+		nodes := make([]*node, len(group))
+		copy(nodes, group)
+		sortNodes(nodes)
+
+		for _, n := range nodes {
+			log.Info("Would move", "Group", groupIndex+1, "Kind", n.identity.GroupVersionKind().String(), "Namespace", n.identity.Namespace, "Name", n.identity.Name)
+		}
+	}
+}
+
 // setClusterPause sets the paused field on a Cluster object.
-func setClusterPause(proxy Proxy, clusters []*node, value bool) error {
+func setClusterPause(ctx context.Context, proxy Proxy, clusters []*node, value bool) error {
 	log := logf.Log
 	patch := client.RawPatch(types.MergePatchType, []byte(fmt.Sprintf("{\"spec\":{\"paused\":%t}}", value)))
 
@@ -324,10 +1899,10 @@ func (o *objectMover) ensureNamespaces(graph *objectGraph, toProxy Proxy) error
 
 	namespaces := sets.NewString()
 	for _, node := range graph.getNodesWithClusterTenants() {
-		namespace := node.identity.Namespace
+		namespace := o.targetNamespace(node.identity.Namespace)
 
-		// If the namespace was already processed, skip it.
-		if namespaces.Has(namespace) {
+		// If the object is cluster-scoped or the namespace was already processed, skip it.
+		if namespace == "" || namespaces.Has(namespace) {
 			continue
 		}
 		namespaces.Insert(namespace)
@@ -338,14 +1913,15 @@ func (o *objectMover) ensureNamespaces(graph *objectGraph, toProxy Proxy) error
 			Name: namespace,
 		}
 
-		if err := cs.Get(ctx, key, ns); err == nil {
-			return nil
+		err := cs.Get(o.getContext(), key, ns)
+		if err == nil {
+			continue
 		}
 		if apierrors.IsForbidden(err) {
 			namespaces := &corev1.NamespaceList{}
 			namespaceExists := false
 			for {
-				if err := cs.List(ctx, namespaces, client.Continue(namespaces.Continue)); err != nil {
+				if err := cs.List(o.getContext(), namespaces, client.Continue(namespaces.Continue)); err != nil {
 					return err
 				}
 
@@ -368,6 +1944,11 @@ func (o *objectMover) ensureNamespaces(graph *objectGraph, toProxy Proxy) error
 			return err
 		}
 
+		// When moving objects to a different namespace, the target namespace is not created.
+		if o.toNamespace != "" {
+			return errors.Errorf("namespace %q does not exist in the target management cluster", namespace)
+		}
+
 		// If the namespace does not exists, create it.
 		ns = &corev1.Namespace{
 			TypeMeta: metav1.TypeMeta{
@@ -379,7 +1960,7 @@ func (o *objectMover) ensureNamespaces(graph *objectGraph, toProxy Proxy) error
 			},
 		}
 		log.V(1).Info("Creating", ns.Kind, ns.Name)
-		if err := cs.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		if err := cs.Create(o.getContext(), ns); err != nil && !apierrors.IsAlreadyExists(err) {
 			return err
 		}
 	}
@@ -387,52 +1968,634 @@ func (o *objectMover) ensureNamespaces(graph *objectGraph, toProxy Proxy) error
 	return nil
 }
 
+// targetNamespace returns the namespace in the target management cluster for a namespace in the source management cluster.
+func (o *objectMover) targetNamespace(namespace string) string {
+	if namespace == "" || o.toNamespace == "" {
+		return namespace
+	}
+	return o.toNamespace
+}
+
+// toTargetNodes returns a copy of the nodes, identifying the corresponding objects in the target management cluster.
+func (o *objectMover) toTargetNodes(nodes []*node) []*node {
+	targetNodes := make([]*node, 0, len(nodes))
+	for _, n := range nodes {
+		identity := n.identity
+		identity.Namespace = o.targetNamespace(identity.Namespace)
+		identity.Name = o.targetName(n)
+		identity.APIVersion = o.targetAPIVersion(n.identity)
+		identity.UID = n.newUID
+		targetNodes = append(targetNodes, &node{identity: identity})
+	}
+	return targetNodes
+}
+
+// setProvenance sets the annotations recording where and when the objects were moved from, if required; all the objects
+// moved by the same operation share the same timestamp.
+func (o *objectMover) setProvenance(options MoveOptions, getSource func() (string, error)) error {
+	o.provenance = nil
+	if !options.AnnotateProvenance {
+		return nil
+	}
+
+	movedFrom := options.MovedFromAnnotation
+	if movedFrom == "" {
+		movedFrom = clusterctlv1.ClusterctlMovedFromAnnotation
+	}
+	movedAt := options.MovedAtAnnotation
+	if movedAt == "" {
+		movedAt = clusterctlv1.ClusterctlMovedAtAnnotation
+	}
+	for _, key := range []string{movedFrom, movedAt} {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return errors.Errorf("invalid provenance annotation %q: %s", key, strings.Join(errs, ", "))
+		}
+	}
+	if movedFrom == movedAt {
+		return errors.Errorf("the provenance annotations must be different, got %q twice", movedFrom)
+	}
+
+	source, err := getSource()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the source of the objects for the provenance annotations")
+	}
+
+	o.provenance = map[string]string{
+		movedFrom: source,
+		movedAt:   time.Now().UTC().Format(time.RFC3339),
+	}
+	return nil
+}
+
+// kubeconfigLogValues returns the key/value pairs identifying a management cluster in the logs, i.e. the kubeconfig context
+// and the API server URL, with the keys prefixed by prefix; credentials are never included. If the kubeconfig can't be read,
+// no values are returned, given that the logs should not prevent the operation.
+func kubeconfigLogValues(prefix string, kubeconfig Kubeconfig) []interface{} {
+	context, server, err := kubeconfigServer(kubeconfig)
+	if err != nil {
+		return nil
+	}
+	return []interface{}{prefix + "Context", context, prefix + "Server", server}
+}
+
+// validateNamespaces checks that the objects to be moved are selected either by a single namespace or by a list of namespaces.
+func validateNamespaces(options MoveOptions) error {
+	if options.Namespace != "" && len(options.Namespaces) > 0 {
+		return errors.New("the namespace and the list of namespaces of the objects to be moved can't be set at the same time")
+	}
+	for _, namespace := range options.Namespaces {
+		if namespace == "" {
+			return errors.New("the list of namespaces of the objects to be moved can't include an empty namespace")
+		}
+	}
+	return nil
+}
+
+// getNamespaces returns the namespaces where the objects to be moved exist; the empty namespace means all the namespaces.
+func getNamespaces(options MoveOptions) []string {
+	if len(options.Namespaces) > 0 {
+		return options.Namespaces
+	}
+	return []string{options.Namespace}
+}
+
+// validateRewriteRefs checks that references to other namespaces are remapped only when moving objects to a target namespace,
+// and that the namespace the objects are moved from, which is always remapped to the target namespace, is not remapped elsewhere.
+func validateRewriteRefs(options MoveOptions) error {
+	if len(options.RewriteRefs) == 0 {
+		return nil
+	}
+	if options.ToNamespace == "" {
+		return errors.New("references to other namespaces can be remapped only when moving objects to a different target namespace")
+	}
+	if _, ok := options.RewriteRefs[options.Namespace]; ok && options.Namespace != "" {
+		return errors.Errorf("references to the namespace %q are always remapped to the target namespace %q", options.Namespace, options.ToNamespace)
+	}
+	return nil
+}
+
+// remapNamespace moves an object to the target namespace, if remapped, together with all the references to other objects in the
+// same source namespace, e.g. Cluster.Spec.InfrastructureRef; references to the namespaces listed in rewriteRefs are remapped too,
+// while cluster-scoped objects and references to other namespaces are left untouched.
+func (o *objectMover) remapNamespace(obj *unstructured.Unstructured) {
+	fromNamespace := obj.GetNamespace()
+	toNamespace := o.targetNamespace(fromNamespace)
+	if toNamespace == fromNamespace {
+		return
+	}
+
+	namespaces := map[string]string{}
+	for from, to := range o.rewriteRefs {
+		namespaces[from] = to
+	}
+	namespaces[fromNamespace] = toNamespace
+
+	remapReferences(obj.Object, namespaces)
+	obj.SetNamespace(toNamespace)
+}
+
+// remapReferences changes the namespace of all the object references nested in value that point to one of the given namespaces.
+func remapReferences(value interface{}, namespaces map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if namespace, ok := v["namespace"].(string); ok {
+			if toNamespace, ok := namespaces[namespace]; ok {
+				if _, ok := v["name"]; ok {
+					v["namespace"] = toNamespace
+				}
+			}
+		}
+		for _, nested := range v {
+			remapReferences(nested, namespaces)
+		}
+	case []interface{}:
+		for _, nested := range v {
+			remapReferences(nested, namespaces)
+		}
+	}
+}
+
+// validateRewriteFinalizers checks that the finalizers to be rewritten are not empty.
+func validateRewriteFinalizers(options MoveOptions) error {
+	for from := range options.RewriteFinalizers {
+		if from == "" {
+			return errors.New("the finalizers to be rewritten can't be empty")
+		}
+	}
+	return nil
+}
+
+// rewriteFinalizers replaces the finalizers of an object listed in finalizers with the corresponding ones, removing the finalizers
+// mapped to an empty string and avoiding duplicates; the order of the finalizers is preserved.
+func rewriteFinalizers(obj *unstructured.Unstructured, finalizers map[string]string) {
+	if len(finalizers) == 0 || len(obj.GetFinalizers()) == 0 {
+		return
+	}
+
+	rewritten := []string{}
+	seen := map[string]bool{}
+	for _, finalizer := range obj.GetFinalizers() {
+		if to, ok := finalizers[finalizer]; ok {
+			finalizer = to
+		}
+		if finalizer == "" || seen[finalizer] {
+			continue
+		}
+		seen[finalizer] = true
+		rewritten = append(rewritten, finalizer)
+	}
+	obj.SetFinalizers(rewritten)
+}
+
+// checkReferences warns about the references to objects in other namespaces that can't be resolved in the target management
+// cluster once an object is moved to the target namespace, e.g. a Secret in a namespace that was not remapped using rewriteRefs.
+// Nb. Only references defining the kind of the referenced object, or fields named secretRef, can be checked.
+func (o *objectMover) checkReferences(obj *unstructured.Unstructured, cTo client.Client) {
+	log := logf.Log
+	for _, ref := range getCrossNamespaceReferences(obj.Object, "", obj.GetNamespace()) {
+		refObj := &unstructured.Unstructured{}
+		refObj.SetAPIVersion(ref.APIVersion)
+		refObj.SetKind(ref.Kind)
+		err := cTo.Get(o.getContext(), client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, refObj)
+		if err == nil {
+			continue
+		}
+		if apierrors.IsNotFound(err) {
+			log.Info("Warning: reference to an object not existing in the target cluster", ref.Kind, ref.Name, "Namespace", ref.Namespace, "Field", ref.FieldPath, "From", fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName()))
+			continue
+		}
+		log.V(1).Info("Failed to check reference", ref.Kind, ref.Name, "Namespace", ref.Namespace, "Field", ref.FieldPath, "Error", err.Error())
+	}
+}
+
+// getCrossNamespaceReferences returns all the object references nested in value that point to a namespace other than the given one.
+// The kind of the referenced object is read from the reference itself; fields named secretRef are assumed to reference a Secret.
+func getCrossNamespaceReferences(value interface{}, field, namespace string) []corev1.ObjectReference {
+	refs := []corev1.ObjectReference{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		name, _ := v["name"].(string)
+		refNamespace, _ := v["namespace"].(string)
+		if name != "" && refNamespace != "" && refNamespace != namespace {
+			ref := corev1.ObjectReference{Namespace: refNamespace, Name: name, FieldPath: field}
+			ref.Kind, _ = v["kind"].(string)
+			ref.APIVersion, _ = v["apiVersion"].(string)
+			if ref.Kind == "" && strings.HasSuffix(strings.ToLower(field), "secretref") {
+				ref.Kind = "Secret"
+				ref.APIVersion = "v1"
+			}
+			if ref.Kind != "" && ref.APIVersion != "" {
+				refs = append(refs, ref)
+			}
+		}
+		for key, nested := range v {
+			refs = append(refs, getCrossNamespaceReferences(nested, joinFieldPath(field, key), namespace)...)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			refs = append(refs, getCrossNamespaceReferences(nested, fmt.Sprintf("%s[%d]", field, i), namespace)...)
+		}
+	}
+	return refs
+}
+
+// joinFieldPath appends a key to a field path, e.g. spec.identityRef.
+func joinFieldPath(field, key string) string {
+	if field == "" {
+		return key
+	}
+	return field + "." + key
+}
+
 // createGroup creates all the Kubernetes objects into the target management cluster corresponding to the object graph nodes in a moveGroup.
 func (o *objectMover) createGroup(group moveGroup, toProxy Proxy) error {
-	createTargetObjectBackoff := newBackoff()
-	errList := []error{}
-	for i := range group {
-		nodeToCreate := group[i]
+	createTargetObjectBackoff := o.newRetryBackoff()
+	return o.processGroup(group, func(nodeToCreate *node) error {
+		// If an object this object depends on failed, skip it, so no object is created with dangling OwnerReferences.
+		if owner := o.getFailedOwner(nodeToCreate); owner != nil {
+			log := logf.Log
+			log.Info("Skipping, because an object it depends on failed", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
+			o.setFailed(nodeToCreate, errors.Errorf("skipped because %s %s/%s failed", owner.identity.Kind, owner.identity.Namespace, owner.identity.Name))
+			return nil
+		}
+
+		// If the object was already created by an interrupted move, skip it but restore its newUID,
+		// so OwnerReferences in the dependent objects can be re-created.
+		if o.state != nil {
+			if newUID, ok := o.state.getCreated(nodeToCreate.identity.UID); ok {
+				log := logf.Log
+				log.V(1).Info("Already created, skipping", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
+				nodeToCreate.newUID = newUID
+				o.recordObject(&o.summary.Skipped, nodeToCreate, nil)
+				return nil
+			}
+		}
 
 		// Creates the Kubernetes object corresponding to the nodeToCreate.
-		// Nb. The operation is wrapped in a retry loop to make move more resilient to unexpected conditions.
-		err := retryWithExponentialBackoff(createTargetObjectBackoff, func() error {
+		// Nb. The operation is wrapped in a retry loop to make move more resilient to transient errors.
+		err := retryWithExponentialBackoffContextIf(o.getContext(), createTargetObjectBackoff, isTransientError, func() error {
 			return o.createTargetObject(nodeToCreate, toProxy)
 		})
 		if err != nil {
-			errList = append(errList, err)
+			o.setFailed(nodeToCreate, err)
+			return err
 		}
+
+		if o.state != nil {
+			return o.state.setCreated(nodeToCreate.identity.UID, nodeToCreate.newUID)
+		}
+		return nil
+	})
+}
+
+// processGroup runs an action for all the nodes in a moveGroup, collecting the errors; given that the nodes in a moveGroup
+// do not depend on each other, up to o.parallelism nodes are processed concurrently.
+func (o *objectMover) processGroup(group moveGroup, action func(n *node) error) error {
+	workers := o.parallelism
+	if workers < 1 {
+		workers = 1
 	}
 
-	if len(errList) > 0 {
-		return kerrors.NewAggregate(errList)
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	errList := []error{}
+	nodes := make(chan *node)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range nodes {
+				// Once the operation is aborted, e.g. because of timeout, no further action is started.
+				if o.getContext().Err() != nil {
+					continue
+				}
+				if err := action(n); err != nil {
+					lock.Lock()
+					errList = append(errList, err)
+					lock.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := range group {
+		nodes <- group[i]
+	}
+	close(nodes)
+	wg.Wait()
+
+	if err := o.getContext().Err(); err != nil && len(errList) == 0 {
+		errList = append(errList, err)
+	}
+	return kerrors.NewAggregate(errList)
+}
+
+// canContinue returns true if the move operation should continue after an error, i.e. when continuing on error and
+// the operation was not aborted, e.g. because of timeout.
+func (o *objectMover) canContinue() bool {
+	return o.continueOnError && o.getContext().Err() == nil
+}
+
+// setFailed records that the object corresponding to a node failed, reporting it in the move summary.
+func (o *objectMover) setFailed(n *node, err error) {
+	o.recordObject(&o.summary.Failed, n, err)
+
+	o.summaryLock.Lock()
+	defer o.summaryLock.Unlock()
+	if o.failedNodes == nil {
+		o.failedNodes = map[*node]bool{}
 	}
+	o.failedNodes[n] = true
+}
 
+// getFailedOwner returns an owner, or soft owner, of a node that failed, if any.
+func (o *objectMover) getFailedOwner(n *node) *node {
+	o.summaryLock.Lock()
+	defer o.summaryLock.Unlock()
+	for owner := range n.owners {
+		if o.failedNodes[owner] {
+			return owner
+		}
+	}
+	for owner := range n.softOwners {
+		if o.failedNodes[owner] {
+			return owner
+		}
+	}
 	return nil
 }
 
+// isRetained returns true if the object corresponding to a node must be kept in the source management cluster, because it
+// failed or because it belongs to a Cluster with failed objects; this prevents deleting the owners of the failed objects,
+// which would cause the garbage collection of the failed objects in the source management cluster.
+func (o *objectMover) isRetained(n *node) bool {
+	o.summaryLock.Lock()
+	defer o.summaryLock.Unlock()
+	if o.failedNodes[n] {
+		return true
+	}
+	for failed := range o.failedNodes {
+		for cluster := range failed.tenantClusters {
+			if _, ok := n.tenantClusters[cluster]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getCompletedClusters returns the Clusters without failed objects, which can be resumed in the target management cluster.
+func (o *objectMover) getCompletedClusters(clusters []*node) []*node {
+	completed := []*node{}
+	for _, cluster := range clusters {
+		if !o.isRetained(cluster) {
+			completed = append(completed, cluster)
+		}
+	}
+	return completed
+}
+
+// getClustersToResume returns the Clusters whose reconciliation should be resumed at the end of the move; when preservePaused
+// is set, the Clusters that were already paused before the move are kept paused.
+func (o *objectMover) getClustersToResume(clusters []*node) []*node {
+	if !o.preservePaused {
+		return clusters
+	}
+
+	log := logf.Log
+	ret := []*node{}
+	for _, cluster := range clusters {
+		if cluster.paused {
+			log.Info("Keeping the Cluster paused, as it was before the move", "Cluster", cluster.identity.Name, "Namespace", cluster.identity.Namespace)
+			continue
+		}
+		ret = append(ret, cluster)
+	}
+	return ret
+}
+
+// getSourceObject reads the Kubernetes object corresponding to the object graph node from the source management cluster or,
+// when restoring objects, from the source directory or archive.
+func (o *objectMover) getSourceObject(nodeToRead *node) (*unstructured.Unstructured, error) {
+	if o.fromArchive != nil {
+		obj, ok := o.fromArchive[nodeToRead.fileName()]
+		if !ok {
+			return nil, errors.Errorf("failed to find %q in the source archive", nodeToRead.fileName())
+		}
+		return obj.DeepCopy(), nil
+	}
+
+	if o.fromDirectory != "" {
+		path := filepath.Join(o.fromDirectory, nodeToRead.fileName())
+		objs, err := readObjectsFromFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(objs) != 1 {
+			return nil, errors.Errorf("expected exactly one object in %q, found %d", path, len(objs))
+		}
+		return &objs[0], nil
+	}
+
+	cFrom, err := o.fromProxy.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// Nb. If the objects are converted, the source API server takes care of returning them in the target version.
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(o.targetAPIVersion(nodeToRead.identity))
+	obj.SetKind(nodeToRead.identity.Kind)
+	objKey := client.ObjectKey{
+		Namespace: nodeToRead.identity.Namespace,
+		Name:      nodeToRead.identity.Name,
+	}
+
+	if err := cFrom.Get(o.getContext(), objKey, obj); err != nil {
+		return nil, errors.Wrapf(err, "error reading %q %s/%s",
+			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+	}
+
+	return obj, nil
+}
+
 // createTargetObject creates the Kubernetes object in the target Management cluster corresponding to the object graph node, taking care of restoring the OwnerReference with the owner nodes, if any.
 func (o *objectMover) createTargetObject(nodeToCreate *node, toProxy Proxy) error {
 	log := logf.Log
 	log.V(1).Info("Creating", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
 
-	cFrom, err := o.fromProxy.NewClient()
+	obj, err := o.getTargetObject(nodeToCreate)
 	if err != nil {
 		return err
 	}
 
-	// Get the source object
-	obj := &unstructured.Unstructured{}
-	obj.SetAPIVersion(nodeToCreate.identity.APIVersion)
-	obj.SetKind(nodeToCreate.identity.Kind)
 	objKey := client.ObjectKey{
-		Namespace: nodeToCreate.identity.Namespace,
-		Name:      nodeToCreate.identity.Name,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+
+	// Creates the targetObj into the target management cluster.
+	cTo, err := toProxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	// Keeps a copy of the object move intends to create, because creating the object updates it with the server response.
+	var intended *unstructured.Unstructured
+	operation := "create"
+	if o.verifyObjects {
+		intended = obj.DeepCopy()
+	}
+
+	err = cTo.Create(o.getContext(), obj)
+	logObjectOperation("Create", obj, err)
+	if err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "error creating %q %s/%s",
+				obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+		}
+
+		// Retrieve the UID and the resource version of the existing object.
+		existingTargetObj := &unstructured.Unstructured{}
+		existingTargetObj.SetAPIVersion(obj.GetAPIVersion())
+		existingTargetObj.SetKind(obj.GetKind())
+		if err := cTo.Get(o.getContext(), objKey, existingTargetObj); err != nil {
+			return errors.Wrapf(err, "error reading resource for %q %s/%s",
+				existingTargetObj.GroupVersionKind(), existingTargetObj.GetNamespace(), existingTargetObj.GetName())
+		}
+
+		// If existing objects must not be overwritten, e.g. after a partial manual migration, keep the existing object,
+		// using its UID for re-creating the OwnerReferences in the dependent objects.
+		if o.skipExisting {
+			log.Info("Object already exists in the target cluster, skipping", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", obj.GetNamespace())
+			if !reflect.DeepEqual(obj.Object["spec"], existingTargetObj.Object["spec"]) {
+				log.Info("Warning: the spec of the existing object differs from the source object", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", obj.GetNamespace())
+			}
+			nodeToCreate.newUID = existingTargetObj.GetUID()
+			o.recordObject(&o.summary.Skipped, nodeToCreate, nil)
+			return nil
+		}
+
+		// When syncing, objects not changed since the previous sync are left untouched.
+		if o.syncMode && !isChanged(obj, existingTargetObj) {
+			log.V(1).Info("Object not changed, skipping", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", obj.GetNamespace())
+			nodeToCreate.newUID = existingTargetObj.GetUID()
+			o.recordObject(&o.summary.Skipped, nodeToCreate, nil)
+			return nil
+		}
+
+		// Otherwise, update the existing object.
+		// Nb. When not syncing, this should not happen, but it is supported to make move more resilient to unexpected interrupt/restarts of the move process.
+		log.V(5).Info("Object already exists, updating", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
+
+		obj.SetUID(existingTargetObj.GetUID())
+		obj.SetResourceVersion(existingTargetObj.GetResourceVersion())
+		operation = "update"
+		err := cTo.Update(o.getContext(), obj)
+		logObjectOperation("Update", obj, err)
+		if err != nil {
+			return errors.Wrapf(err, "error updating %q %s/%s",
+				obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+		}
+	}
+
+	// Checks that the object landed in the target management cluster as intended, if required.
+	if o.verifyObjects {
+		if err := o.verifyObject(nodeToCreate, intended, operation, cTo); err != nil {
+			return err
+		}
+	}
+
+	// Warns about references to other namespaces that can't be resolved once the object is moved to the target namespace.
+	if o.toNamespace != "" {
+		o.checkReferences(obj, cTo)
+	}
+
+	// Stores the newUID assigned to the newly created object.
+	nodeToCreate.newUID = obj.GetUID()
+	o.recordObject(&o.summary.Moved, nodeToCreate, nil)
+
+	return nil
+}
+
+// verifyObject reads back an object created or updated in the target management cluster and compares it with the object move
+// intended to create, ignoring the status and the metadata managed by the server, e.g. the UID, the resource version or the
+// managed fields; drift, e.g. caused by defaulting or mutating webhooks, is logged and recorded in the summary without failing the move.
+func (o *objectMover) verifyObject(n *node, intended *unstructured.Unstructured, operation string, cTo client.Client) error {
+	actual := &unstructured.Unstructured{}
+	actual.SetAPIVersion(intended.GetAPIVersion())
+	actual.SetKind(intended.GetKind())
+	if err := cTo.Get(o.getContext(), client.ObjectKey{Namespace: intended.GetNamespace(), Name: intended.GetName()}, actual); err != nil {
+		return errors.Wrapf(err, "error reading %q %s/%s for verifying it", intended.GroupVersionKind(), intended.GetNamespace(), intended.GetName())
+	}
+
+	if !isChanged(intended, actual) {
+		return nil
+	}
+
+	patch, err := client.MergeFrom(diffableObject(intended)).Data(diffableObject(actual))
+	if err != nil {
+		return errors.Wrapf(err, "failed to compute the patch for %q %s/%s", intended.GroupVersionKind(), intended.GetNamespace(), intended.GetName())
+	}
+
+	log := logf.Log
+	log.Info("Warning: the object in the target cluster differs from the object created by move", n.identity.Kind, n.identity.Name, "Namespace", intended.GetNamespace(), "Patch", string(patch))
+
+	o.summaryLock.Lock()
+	defer o.summaryLock.Unlock()
+	o.summary.Drift = append(o.summary.Drift, ObjectDiff{
+		MoveObject: MoveObject{
+			APIVersion: n.identity.APIVersion,
+			Kind:       n.identity.Kind,
+			Namespace:  n.identity.Namespace,
+			Name:       n.identity.Name,
+		},
+		Operation: operation,
+		Patch:     string(patch),
+	})
+	return nil
+}
+
+// getTargetObject returns the object corresponding to a node as it should be created in the target management cluster, i.e.
+// the source object transformed, remapped to the target namespace and with the OwnerReferences re-created using the UIDs of
+// the owners in the target management cluster.
+func (o *objectMover) getTargetObject(nodeToCreate *node) (*unstructured.Unstructured, error) {
+	// Get the source object
+	obj, err := o.getSourceObject(nodeToCreate)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := cFrom.Get(ctx, objKey, obj); err != nil {
-		return errors.Wrapf(err, "error reading %q %s/%s",
-			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+	// Applies the transformers, if any, before remapping the object to the target management cluster.
+	if err := transformObject(obj, o.transformers); err != nil {
+		return nil, err
+	}
+
+	// Moves the object to the target namespace, if remapped.
+	o.remapNamespace(obj)
+
+	// Renames the object and the references to the renamed objects, if required.
+	o.renameObject(nodeToCreate, obj)
+
+	// Rewrites the finalizers specific to the source management cluster, if required.
+	rewriteFinalizers(obj, o.rewriteFinalizers)
+
+	// Records where and when the object was moved from, if required.
+	if len(o.provenance) > 0 {
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		for key, value := range o.provenance {
+			annotations[key] = value
+		}
+		obj.SetAnnotations(annotations)
+	}
+	// When syncing, Clusters are kept paused in the target management cluster, given that they are still active in the source.
+	if o.syncMode && nodeToCreate.identity.GroupVersionKind().GroupKind() == clusterv1.GroupVersion.WithKind("Cluster").GroupKind() {
+		if err := unstructured.SetNestedField(obj.Object, true, "spec", "paused"); err != nil {
+			return nil, errors.Wrapf(err, "failed to set the paused field on %q %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+		}
 	}
 
 	// New objects cannot have a specified resource version. Clear it out.
@@ -446,9 +2609,9 @@ func (o *objectMover) createTargetObject(nodeToCreate *node, toProxy Proxy) erro
 		ownerRefs := []metav1.OwnerReference{}
 		for ownerNode := range nodeToCreate.owners {
 			ownerRef := metav1.OwnerReference{
-				APIVersion: ownerNode.identity.APIVersion,
+				APIVersion: o.targetAPIVersion(ownerNode.identity),
 				Kind:       ownerNode.identity.Kind,
-				Name:       ownerNode.identity.Name,
+				Name:       o.targetName(ownerNode),
 				UID:        ownerNode.newUID, // Use the owner's newUID read from the target management cluster (instead of the UID read during discovery).
 			}
 
@@ -461,67 +2624,244 @@ func (o *objectMover) createTargetObject(nodeToCreate *node, toProxy Proxy) erro
 			ownerRefs = append(ownerRefs, ownerRef)
 		}
 		obj.SetOwnerReferences(ownerRefs)
+	}
 
+	return obj, nil
+}
+
+// isChanged returns true if an object read from the source management cluster differs from the corresponding object existing
+// in the target management cluster, ignoring the status and the metadata other than labels, annotations and owner references.
+func isChanged(obj, existing *unstructured.Unstructured) bool {
+	if !reflect.DeepEqual(obj.GetLabels(), existing.GetLabels()) || !reflect.DeepEqual(obj.GetAnnotations(), existing.GetAnnotations()) {
+		return true
+	}
+	// Nb. OwnerReferences are compared regardless of their order, because they are re-created from the object graph.
+	existingOwners := map[types.UID]metav1.OwnerReference{}
+	for _, ref := range existing.GetOwnerReferences() {
+		existingOwners[ref.UID] = ref
+	}
+	if len(obj.GetOwnerReferences()) != len(existingOwners) {
+		return true
+	}
+	for _, ref := range obj.GetOwnerReferences() {
+		if existingRef, ok := existingOwners[ref.UID]; !ok || !reflect.DeepEqual(ref, existingRef) {
+			return true
+		}
+	}
+	for key, value := range obj.Object {
+		if key == "metadata" || key == "status" {
+			continue
+		}
+		if !reflect.DeepEqual(value, existing.Object[key]) {
+			return true
+		}
+	}
+	for key := range existing.Object {
+		if _, ok := obj.Object[key]; !ok && key != "metadata" && key != "status" {
+			return true
+		}
 	}
+	return false
+}
+
+// diffObjects computes, group by group, whether each object in the move sequence would be created, updated or left unchanged
+// in the target management cluster by a sync, recording it in the summary without making any change.
+// Nb. Objects are processed in the order of the move sequence, sorted within each group, so the output is stable across runs.
+func (o *objectMover) diffObjects(moveSequence *moveSequence, toProxy Proxy) error {
+	log := logf.Log
 
-	// Creates the targetObj into the target management cluster.
 	cTo, err := toProxy.NewClient()
 	if err != nil {
 		return err
 	}
 
-	if err := cTo.Create(ctx, obj); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			return errors.Wrapf(err, "error creating %q %s/%s",
-				obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
-		}
+	for groupIndex := range moveSequence.groups {
+		group := moveSequence.getGroup(groupIndex)
+		nodes := make([]*node, len(group))
+		copy(nodes, group)
+		sortNodes(nodes)
 
-		// If the object already exists, try to update it.
-		// Nb. This should not happen, but it is supported to make move more resilient to unexpected interrupt/restarts of the move process.
-		log.V(5).Info("Object already exists, updating", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
+		for _, n := range nodes {
+			obj, err := o.getTargetObject(n)
+			if err != nil {
+				return err
+			}
 
-		// Retrieve the UID and the resource version for the update.
-		existingTargetObj := &unstructured.Unstructured{}
-		existingTargetObj.SetAPIVersion(obj.GetAPIVersion())
-		existingTargetObj.SetKind(obj.GetKind())
-		if err := cTo.Get(ctx, objKey, existingTargetObj); err != nil {
-			return errors.Wrapf(err, "error reading resource for %q %s/%s",
-				existingTargetObj.GroupVersionKind(), existingTargetObj.GetNamespace(), existingTargetObj.GetName())
-		}
+			diff := ObjectDiff{
+				MoveObject: MoveObject{
+					APIVersion: n.identity.APIVersion,
+					Kind:       n.identity.Kind,
+					Namespace:  n.identity.Namespace,
+					Name:       n.identity.Name,
+				},
+			}
 
-		obj.SetUID(existingTargetObj.GetUID())
-		obj.SetResourceVersion(existingTargetObj.GetResourceVersion())
-		if err := cTo.Update(ctx, obj); err != nil {
-			return errors.Wrapf(err, "error updating %q %s/%s",
-				obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
-		}
-	}
+			existing := &unstructured.Unstructured{}
+			existing.SetAPIVersion(obj.GetAPIVersion())
+			existing.SetKind(obj.GetKind())
+			if err := cTo.Get(o.getContext(), client.ObjectKey{Namespace: obj.GetNamespace(), Name: obj.GetName()}, existing); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return errors.Wrapf(err, "error reading %q %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+				}
+				diff.Operation = "create"
+				log.Info("Would create", n.identity.Kind, n.identity.Name, "Namespace", n.identity.Namespace)
+				o.summary.Diff = append(o.summary.Diff, diff)
+				continue
+			}
 
-	// Stores the newUID assigned to the newly created object.
-	nodeToCreate.newUID = obj.GetUID()
+			// Uses the UID of the existing object for re-creating the OwnerReferences in the dependent objects.
+			n.newUID = existing.GetUID()
+
+			if !isChanged(obj, existing) {
+				diff.Operation = "unchanged"
+				log.V(1).Info("Would leave unchanged", n.identity.Kind, n.identity.Name, "Namespace", n.identity.Namespace)
+				o.summary.Diff = append(o.summary.Diff, diff)
+				continue
+			}
 
+			patch, err := client.MergeFrom(diffableObject(existing)).Data(diffableObject(obj))
+			if err != nil {
+				return errors.Wrapf(err, "failed to compute the patch for %q %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+			}
+			diff.Operation = "update"
+			diff.Patch = string(patch)
+			log.Info("Would update", n.identity.Kind, n.identity.Name, "Namespace", n.identity.Namespace, "Patch", diff.Patch)
+			o.summary.Diff = append(o.summary.Diff, diff)
+		}
+	}
 	return nil
 }
 
+// diffableObject returns a copy of an object with only the fields compared by isChanged, so the patch between two objects
+// does not include e.g. the status, the UID or the resource version.
+// Nb. The copy is shallow, so it must not be modified.
+func diffableObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	ret := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	for key, value := range obj.Object {
+		if key != "metadata" && key != "status" {
+			ret.Object[key] = value
+		}
+	}
+	ret.SetLabels(obj.GetLabels())
+	ret.SetAnnotations(obj.GetAnnotations())
+	ret.SetOwnerReferences(obj.GetOwnerReferences())
+	return ret
+}
+
 // deleteGroup deletes all the Kubernetes objects from the source management cluster corresponding to the object graph nodes in a moveGroup.
 func (o *objectMover) deleteGroup(group moveGroup) error {
-	deleteSourceObjectBackoff := newBackoff()
-	errList := []error{}
-	for i := range group {
-		nodeToDelete := group[i]
+	deleteSourceObjectBackoff := o.newRetryBackoff()
+	return o.processGroup(group, func(nodeToDelete *node) error {
+		// If the object, or other objects belonging to the same Clusters, failed, keep it in the source cluster.
+		if o.isRetained(nodeToDelete) {
+			log := logf.Log
+			log.V(1).Info("Keeping in the source cluster, because objects of the same Cluster failed", nodeToDelete.identity.Kind, nodeToDelete.identity.Name, "Namespace", nodeToDelete.identity.Namespace)
+			return nil
+		}
+
+		// If the object was already deleted by an interrupted move, skip it.
+		if o.state != nil && o.state.isDeleted(nodeToDelete.identity.UID) {
+			return nil
+		}
 
 		// Delete the Kubernetes object corresponding to the current node.
-		// Nb. The operation is wrapped in a retry loop to make move more resilient to unexpected conditions.
-		err := retryWithExponentialBackoff(deleteSourceObjectBackoff, func() error {
+		// Nb. The operation is wrapped in a retry loop to make move more resilient to transient errors.
+		err := retryWithExponentialBackoffContextIf(o.getContext(), deleteSourceObjectBackoff, isTransientError, func() error {
 			return o.deleteSourceObject(nodeToDelete)
 		})
+		if err != nil {
+			o.setFailed(nodeToDelete, err)
+			return err
+		}
+
+		if o.state != nil {
+			return o.state.setDeleted(nodeToDelete.identity.UID)
+		}
+		return nil
+	})
+}
+
+// saveGroup saves all the Kubernetes objects corresponding to the object graph nodes in a moveGroup using the given writer.
+func (o *objectMover) saveGroup(group moveGroup, w objectWriter) error {
+	return o.processGroup(group, func(nodeToSave *node) error {
+		return o.saveSourceObject(nodeToSave, w)
+	})
+}
+
+// saveSourceObject saves the Kubernetes object corresponding to the object graph node to a YAML file using the given writer.
+// Nb. The object is saved as it is, including UID and OwnerReferences, so the object graph can be rebuilt when restoring.
+func (o *objectMover) saveSourceObject(nodeToSave *node, w objectWriter) error {
+	log := logf.Log
+	log.V(1).Info("Saving", nodeToSave.identity.Kind, nodeToSave.identity.Name, "Namespace", nodeToSave.identity.Namespace)
+
+	cFrom, err := o.fromProxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	// Get the source object
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(nodeToSave.identity.APIVersion)
+	obj.SetKind(nodeToSave.identity.Kind)
+	objKey := client.ObjectKey{
+		Namespace: nodeToSave.identity.Namespace,
+		Name:      nodeToSave.identity.Name,
+	}
+
+	if err := cFrom.Get(o.getContext(), objKey, obj); err != nil {
+		return errors.Wrapf(err, "error reading %q %s/%s",
+			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+	}
+
+	data, err := utilyaml.FromUnstructured([]unstructured.Unstructured{*obj})
+	if err != nil {
+		return err
+	}
+
+	if err := w.writeFile(nodeToSave.fileName(), data); err != nil {
+		return errors.Wrapf(err, "error writing %q %s/%s",
+			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+	}
+
+	return nil
+}
+
+// readObjectsFromDirectory reads all the Kubernetes objects saved in the YAML files existing in a directory.
+func readObjectsFromDirectory(directory string) ([]unstructured.Unstructured, error) {
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the source directory %q", directory)
+	}
 
+	objs := []unstructured.Unstructured{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".yaml" {
+			continue
+		}
+
+		fileObjs, err := readObjectsFromFile(filepath.Join(directory, file.Name()))
 		if err != nil {
-			errList = append(errList, err)
+			return nil, err
 		}
+		objs = append(objs, fileObjs...)
 	}
 
-	return kerrors.NewAggregate(errList)
+	return objs, nil
+}
+
+// readObjectsFromFile reads all the Kubernetes objects in a YAML file.
+func readObjectsFromFile(path string) ([]unstructured.Unstructured, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %q", path)
+	}
+
+	objs, err := utilyaml.ToUnstructured(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %q", path)
+	}
+
+	return objs, nil
 }
 
 var (
@@ -548,7 +2888,7 @@ func (o *objectMover) deleteSourceObject(nodeToDelete *node) error {
 		Name:      nodeToDelete.identity.Name,
 	}
 
-	if err := cFrom.Get(ctx, sourceObjKey, sourceObj); err != nil {
+	if err := cFrom.Get(o.getContext(), sourceObjKey, sourceObj); err != nil {
 		if apierrors.IsNotFound(err) {
 			//If the object is already deleted, move on.
 			log.V(5).Info("Object already deleted, skipping delete for", nodeToDelete.identity.Kind, nodeToDelete.identity.Name, "Namespace", nodeToDelete.identity.Namespace)
@@ -559,13 +2899,17 @@ func (o *objectMover) deleteSourceObject(nodeToDelete *node) error {
 	}
 
 	if len(sourceObj.GetFinalizers()) > 0 {
-		if err := cFrom.Patch(ctx, sourceObj, removeFinalizersPatch); err != nil {
+		err := cFrom.Patch(o.getContext(), sourceObj, removeFinalizersPatch)
+		logObjectOperation("Remove finalizers", sourceObj, err)
+		if err != nil {
 			return errors.Wrapf(err, "error removing finalizers from %q %s/%s",
 				sourceObj.GroupVersionKind(), sourceObj.GetNamespace(), sourceObj.GetName())
 		}
 	}
 
-	if err := cFrom.Delete(ctx, sourceObj); err != nil {
+	err = cFrom.Delete(o.getContext(), sourceObj)
+	logObjectOperation("Delete", sourceObj, err)
+	if err != nil {
 		return errors.Wrapf(err, "error deleting %q %s/%s",
 			sourceObj.GroupVersionKind(), sourceObj.GetNamespace(), sourceObj.GetName())
 	}
@@ -573,6 +2917,316 @@ func (o *objectMover) deleteSourceObject(nodeToDelete *node) error {
 	return nil
 }
 
+// waitSourceDeleted waits, with an exponential backoff bounded by deleteTimeout, for all the objects in the move sequence
+// to be deleted from the source management cluster; if some objects still exist when the timeout expires, they are
+// logged together with their remaining finalizers, and an error listing them is returned.
+func (o *objectMover) waitSourceDeleted(moveSequence *moveSequence) error {
+	timeout := o.deleteTimeout
+	if timeout <= 0 {
+		timeout = defaultDeleteTimeout
+	}
+
+	cFrom, err := o.fromProxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	nodes := []*node{}
+	for _, group := range moveSequence.groups {
+		for _, n := range group {
+			if !o.isRetained(n) {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+
+	backoff := wait.Backoff{
+		Duration: waitSourceDeletedInterval,
+		Factor:   2,
+		Steps:    math.MaxInt32,
+		Cap:      maxWaitSourceDeletedInterval,
+	}
+	deadline := time.Now().Add(timeout)
+	var notDeleted []*unstructured.Unstructured
+	for {
+		notDeleted, err = o.getNotDeletedObjects(cFrom, nodes)
+		if err == nil && len(notDeleted) == 0 {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		delay := backoff.Step()
+		if delay > remaining {
+			delay = remaining
+		}
+		select {
+		case <-time.After(delay):
+		case <-o.getContext().Done():
+			return o.getContext().Err()
+		}
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "failed to check the objects deleted from the source cluster")
+	}
+
+	log := logf.Log
+	stuck := make([]string, 0, len(notDeleted))
+	for _, obj := range notDeleted {
+		log.Info("Object not deleted from the source cluster", obj.GetKind(), obj.GetName(), "Namespace", obj.GetNamespace(), "Finalizers", obj.GetFinalizers())
+		description := fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		if len(obj.GetFinalizers()) > 0 {
+			description += fmt.Sprintf(" (finalizers: %s)", strings.Join(obj.GetFinalizers(), ", "))
+		}
+		stuck = append(stuck, description)
+	}
+	return errors.Errorf("timed out after %s waiting for objects to be deleted from the source cluster, objects not deleted: %s", timeout, strings.Join(stuck, "; "))
+}
+
+// waitForTargetProvisioned waits, if required, for the moved Clusters to be ready and for the moved Machines to have a NodeRef
+// in the target management cluster; if some objects are still not provisioned when the timeout expires, an error listing them
+// is returned.
+func (o *objectMover) waitForTargetProvisioned(graph *objectGraph, toProxy Proxy) error {
+	if !o.waitForCompletion {
+		return nil
+	}
+
+	log := logf.Log
+	log.Info("Waiting for the Clusters and the Machines to be provisioned in the target cluster")
+	return o.runPhase("waiting for the Clusters and the Machines to be provisioned in the target cluster", func() error {
+		timeout := o.waitForCompletionTimeout
+		if timeout <= 0 {
+			timeout = defaultWaitForCompletionTimeout
+		}
+
+		cTo, err := toProxy.NewClient()
+		if err != nil {
+			return err
+		}
+
+		clusters := o.toTargetNodes(graph.getClusters())
+		machines := o.toTargetNodes(graph.getMachines())
+
+		var notProvisioned []string
+		if err := o.pollImmediateWaiter(waitForCompletionInterval, timeout, func() (bool, error) {
+			notProvisioned, err = getNotProvisionedObjects(o.getContext(), cTo, clusters, machines)
+			if err != nil {
+				//Nb. we are ignoring the error so the pollImmediateWaiter will execute another retry
+				return false, nil
+			}
+			return len(notProvisioned) == 0, nil
+		}); err != nil {
+			if len(notProvisioned) == 0 {
+				return errors.Wrap(err, "failed to check the objects moved to the target cluster")
+			}
+			return errors.Wrapf(err, "the objects moved to the target cluster are not provisioned, objects not provisioned: %s", strings.Join(notProvisioned, ", "))
+		}
+		return nil
+	})
+}
+
+// getNotProvisionedObjects returns the Clusters that are not ready and the Machines without a NodeRef, using the same
+// criteria checked before starting the move operation.
+func getNotProvisionedObjects(ctx context.Context, c client.Client, clusters, machines []*node) ([]string, error) {
+	notProvisioned := []string{}
+	for _, cluster := range clusters {
+		clusterObj := &clusterv1.Cluster{}
+		key := client.ObjectKey{Namespace: cluster.identity.Namespace, Name: cluster.identity.Name}
+		if err := c.Get(ctx, key, clusterObj); err != nil {
+			return nil, errors.Wrapf(err, "error reading Cluster %s/%s", key.Namespace, key.Name)
+		}
+
+		if !clusterObj.Status.InfrastructureReady || !clusterObj.Status.ControlPlaneInitialized ||
+			(clusterObj.Spec.ControlPlaneRef != nil && !clusterObj.Status.ControlPlaneReady) {
+			notProvisioned = append(notProvisioned, fmt.Sprintf("Cluster %s/%s", key.Namespace, key.Name))
+		}
+	}
+
+	for _, machine := range machines {
+		machineObj := &clusterv1.Machine{}
+		key := client.ObjectKey{Namespace: machine.identity.Namespace, Name: machine.identity.Name}
+		if err := c.Get(ctx, key, machineObj); err != nil {
+			return nil, errors.Wrapf(err, "error reading Machine %s/%s", key.Namespace, key.Name)
+		}
+
+		if machineObj.Status.NodeRef == nil {
+			notProvisioned = append(notProvisioned, fmt.Sprintf("Machine %s/%s", key.Namespace, key.Name))
+		}
+	}
+	return notProvisioned, nil
+}
+
+// getNotDeletedObjects returns the objects corresponding to the given nodes that still exist in the source management cluster.
+func (o *objectMover) getNotDeletedObjects(cFrom client.Client, nodes []*node) ([]*unstructured.Unstructured, error) {
+	notDeleted := []*unstructured.Unstructured{}
+	for _, n := range nodes {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(n.identity.APIVersion)
+		obj.SetKind(n.identity.Kind)
+		key := client.ObjectKey{
+			Namespace: n.identity.Namespace,
+			Name:      n.identity.Name,
+		}
+
+		if err := cFrom.Get(o.getContext(), key, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "error reading %q %s/%s", obj.GroupVersionKind(), key.Namespace, key.Name)
+		}
+		notDeleted = append(notDeleted, obj)
+	}
+	return notDeleted, nil
+}
+
+// checkTargetCRDs checks that all the CRDs installed by clusterctl in the source cluster are installed in the target cluster too,
+// and that the objects can be created in the target cluster. If the version used for storing the objects in the source cluster
+// is not served by the target cluster, e.g. because the target cluster runs a newer release of a provider, the objects are moved
+// using the version preferred by the target cluster among the ones served by both clusters, relying on the source API server for
+// converting them.
+func (o *objectMover) checkTargetCRDs(toProxy Proxy) error {
+	cFrom, err := o.fromProxy.NewClient()
+	if err != nil {
+		return err
+	}
+	fromCRDs := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := cFrom.List(o.getContext(), fromCRDs, client.MatchingLabels{clusterctlv1.ClusterctlLabelName: ""}); err != nil {
+		return errors.Wrap(err, "failed to get the list of CRDs from the source cluster")
+	}
+
+	cTo, err := toProxy.NewClient()
+	if err != nil {
+		return err
+	}
+	toCRDs := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := cTo.List(o.getContext(), toCRDs); err != nil {
+		return errors.Wrap(err, "failed to get the list of CRDs from the target cluster")
+	}
+
+	targetVersions := map[string][]string{}
+	for _, crd := range toCRDs.Items {
+		targetVersions[crd.Name] = servedVersions(crd)
+	}
+
+	o.targetVersions = map[schema.GroupKind]string{}
+	errList := []error{}
+	for _, crd := range fromCRDs.Items {
+		versions, ok := targetVersions[crd.Name]
+		if !ok {
+			errList = append(errList, errors.Errorf("CRD %s not found in the target cluster", crd.Name))
+			continue
+		}
+		for _, storage := range crd.Spec.Versions {
+			if !storage.Storage || sets.NewString(versions...).Has(storage.Name) {
+				continue
+			}
+
+			// The version stored in the source cluster is not served by the target cluster, so the objects are converted
+			// to the version preferred by the target cluster among the ones served by the source cluster too, if any.
+			fromVersions := servedVersions(crd)
+			converted := false
+			for _, version := range versions {
+				if sets.NewString(fromVersions...).Has(version) {
+					o.targetVersions[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}] = version
+					converted = true
+					break
+				}
+			}
+			if !converted {
+				errList = append(errList, errors.Errorf("CRD %s can't be moved: the source cluster stores version %s and serves versions %s, while the target cluster serves versions %s",
+					crd.Name, storage.Name, strings.Join(fromVersions, ", "), strings.Join(versions, ", ")))
+			}
+		}
+	}
+
+	return kerrors.NewAggregate(errList)
+}
+
+// servedVersions returns the versions served by a CRD, sorted by priority, the preferred version first.
+func servedVersions(crd apiextensionsv1.CustomResourceDefinition) []string {
+	versions := []string{}
+	for _, version := range crd.Spec.Versions {
+		if version.Served {
+			versions = append(versions, version.Name)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return kubeversion.CompareKubeAwareVersionStrings(versions[i], versions[j]) > 0
+	})
+	return versions
+}
+
+// targetAPIVersion returns the API version to be used for an object in the target management cluster; it differs from the
+// API version of the object in the source management cluster only if the objects of the same kind are converted.
+func (o *objectMover) targetAPIVersion(identity corev1.ObjectReference) string {
+	gvk := identity.GroupVersionKind()
+	if version, ok := o.targetVersions[gvk.GroupKind()]; ok {
+		return schema.GroupVersion{Group: gvk.Group, Version: version}.String()
+	}
+	return identity.APIVersion
+}
+
+// waitTargetReady waits for the Deployments of all the providers installed in the target cluster to be available.
+func (o *objectMover) waitTargetReady(toCluster Client, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = defaultTargetReadyTimeout
+	}
+
+	providers, err := toCluster.ProviderInventory().List()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get provider list from the target cluster")
+	}
+
+	c, err := toCluster.Proxy().NewClient()
+	if err != nil {
+		return err
+	}
+
+	log := logf.Log
+	log.Info("Waiting for the providers in the target cluster to be available...")
+	var notAvailable []string
+	if err := o.pollImmediateWaiter(waitTargetReadyInterval, timeout, func() (bool, error) {
+		notAvailable, err = getNotAvailableDeployments(o.getContext(), c, providers.Items)
+		if err != nil {
+			//Nb. we are ignoring the error so the pollImmediateWaiter will execute another retry
+			return false, nil
+		}
+		return len(notAvailable) == 0, nil
+	}); err != nil {
+		if len(notAvailable) == 0 {
+			return errors.Wrap(err, "failed to check the providers in the target cluster")
+		}
+		return &TargetNotReadyError{Deployments: notAvailable, err: err}
+	}
+
+	return nil
+}
+
+// getNotAvailableDeployments returns the Deployments of the providers that do not have all the desired replicas available.
+func getNotAvailableDeployments(ctx context.Context, c client.Client, providers []clusterctlv1.Provider) ([]string, error) {
+	notAvailable := []string{}
+	for _, provider := range providers {
+		deployments := &appsv1.DeploymentList{}
+		if err := c.List(ctx, deployments, client.InNamespace(provider.Namespace), client.MatchingLabels{clusterv1.ProviderLabelName: provider.ManifestLabel()}); err != nil {
+			return nil, errors.Wrapf(err, "failed to get the Deployments for the %s provider", provider.InstanceName())
+		}
+
+		for _, d := range deployments.Items {
+			replicas := int32(1)
+			if d.Spec.Replicas != nil {
+				replicas = *d.Spec.Replicas
+			}
+			if d.Status.AvailableReplicas < replicas {
+				notAvailable = append(notAvailable, fmt.Sprintf("%s/%s (%d/%d available replicas)", d.Namespace, d.Name, d.Status.AvailableReplicas, replicas))
+			}
+		}
+	}
+	return notAvailable, nil
+}
+
 // checkTargetProviders checks that all the providers installed in the source cluster exists in the target cluster as well (with a version >= of the current version).
 func (o *objectMover) checkTargetProviders(namespace string, toInventory InventoryClient) error {
 	// Gets the list of providers in the source/target cluster.
@@ -587,7 +3241,7 @@ func (o *objectMover) checkTargetProviders(namespace string, toInventory Invento
 	}
 
 	// Checks all the providers installed in the source cluster
-	errList := []error{}
+	missing := &MissingProvidersError{}
 	for _, sourceProvider := range fromProviders.Items {
 		// If we are moving objects in a namespace only, skip all the providers not watching such namespace.
 		if namespace != "" && !(sourceProvider.WatchedNamespace == "" || sourceProvider.WatchedNamespace == namespace) {
@@ -614,9 +3268,9 @@ func (o *objectMover) checkTargetProviders(namespace string, toInventory Invento
 				continue
 			}
 
-			// If we are moving objects in a namespace only, skip all the providers not watching such namespace.
+			// If we are moving objects in a namespace only, skip all the providers not watching such namespace (or the target namespace, if remapped).
 			// NB. This means that when moving a single namespace, we use a lazy matching (the watching namespace MUST overlap; exact match is not required).
-			if namespace != "" && !(targetProvider.WatchedNamespace == "" || targetProvider.WatchedNamespace == namespace) {
+			if namespace != "" && !(targetProvider.WatchedNamespace == "" || targetProvider.WatchedNamespace == o.targetNamespace(namespace)) {
 				continue
 			}
 
@@ -631,16 +3285,21 @@ func (o *objectMover) checkTargetProviders(namespace string, toInventory Invento
 		if maxTargetVersion == nil {
 			watching := sourceProvider.WatchedNamespace
 			if namespace != "" {
-				watching = namespace
+				watching = o.targetNamespace(namespace)
 			}
-			errList = append(errList, errors.Errorf("provider %s watching namespace %s not found in the target cluster", sourceProvider.Name, watching))
+			missing.Providers = append(missing.Providers, sourceProvider.Name)
+			missing.errs = append(missing.errs, errors.Errorf("provider %s watching namespace %s not found in the target cluster", sourceProvider.Name, watching))
 			continue
 		}
 
 		if !maxTargetVersion.AtLeast(sourceVersion) {
-			errList = append(errList, errors.Errorf("provider %s in the target cluster is older than in the source cluster (source: %s, target: %s)", sourceProvider.Name, sourceVersion.String(), maxTargetVersion.String()))
+			missing.Providers = append(missing.Providers, sourceProvider.Name)
+			missing.errs = append(missing.errs, errors.Errorf("provider %s in the target cluster is older than in the source cluster (source: %s, target: %s)", sourceProvider.Name, sourceVersion.String(), maxTargetVersion.String()))
 		}
 	}
 
-	return kerrors.NewAggregate(errList)
+	if len(missing.errs) > 0 {
+		return missing
+	}
+	return nil
 }
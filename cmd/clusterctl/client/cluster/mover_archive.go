@@ -0,0 +1,257 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilyaml "sigs.k8s.io/cluster-api/cmd/clusterctl/internal/util"
+)
+
+// objectWriter writes the files with the objects saved by move, one file for each object, together with a manifest
+// listing the checksum of all the files.
+// Nb. Objects in the same moveGroup are saved concurrently, so writeFile must be safe for concurrent use.
+type objectWriter interface {
+	// open prepares the writer, e.g. creating the target directory.
+	open() error
+
+	// writeFile writes a file with the given name and content.
+	writeFile(name string, data []byte) error
+
+	// writeManifest writes the manifest listing the checksum of the given files, already written.
+	writeManifest(fileNames []string, source Kubeconfig) error
+
+	// close releases the resources used by the writer, e.g. flushing an archive.
+	close() error
+}
+
+// directoryWriter writes the files with the objects saved by move to a directory.
+type directoryWriter struct {
+	directory string
+}
+
+var _ objectWriter = &directoryWriter{}
+
+func newDirectoryWriter(directory string) *directoryWriter {
+	return &directoryWriter{directory: directory}
+}
+
+func (w *directoryWriter) open() error {
+	if err := os.MkdirAll(w.directory, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create the target directory %q", w.directory)
+	}
+	return nil
+}
+
+func (w *directoryWriter) writeFile(name string, data []byte) error {
+	// Nb. Files are readable only by the current user, because saved objects include Secrets.
+	path := filepath.Join(w.directory, name)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write %q", path)
+	}
+	return nil
+}
+
+func (w *directoryWriter) writeManifest(fileNames []string, source Kubeconfig) error {
+	return writeManifest(w.directory, fileNames, source)
+}
+
+func (w *directoryWriter) close() error {
+	return nil
+}
+
+// archiveWriter streams the files with the objects saved by move into a gzip-compressed tarball as soon as each object
+// is read, so the archive is never built in memory, e.g. when saving very large management clusters.
+type archiveWriter struct {
+	path string
+
+	lock      sync.Mutex
+	file      *os.File
+	gzip      *gzip.Writer
+	tar       *tar.Writer
+	checksums map[string]string
+}
+
+var _ objectWriter = &archiveWriter{}
+
+func newArchiveWriter(path string) *archiveWriter {
+	return &archiveWriter{path: path}
+}
+
+func (w *archiveWriter) open() error {
+	// Nb. The archive is readable only by the current user, because saved objects include Secrets.
+	file, err := os.OpenFile(w.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the target archive %q", w.path)
+	}
+	w.file = file
+	w.gzip = gzip.NewWriter(file)
+	w.tar = tar.NewWriter(w.gzip)
+	w.checksums = map[string]string{}
+	return nil
+}
+
+func (w *archiveWriter) writeFile(name string, data []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if err := w.writeEntry(name, data); err != nil {
+		return err
+	}
+	w.checksums[name] = dataChecksum(data)
+	return nil
+}
+
+func (w *archiveWriter) writeManifest(fileNames []string, source Kubeconfig) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	checksums := map[string]string{}
+	for _, fileName := range fileNames {
+		checksum, ok := w.checksums[fileName]
+		if !ok {
+			return errors.Errorf("failed to write the manifest: %q was not written to the archive %q", fileName, w.path)
+		}
+		checksums[fileName] = checksum
+	}
+	return w.writeEntry(manifestFileName, formatManifest(checksums, source))
+}
+
+// writeEntry writes a file to the archive; the caller must hold the lock.
+func (w *archiveWriter) writeEntry(name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := w.tar.WriteHeader(header); err != nil {
+		return errors.Wrapf(err, "failed to write %q to the archive %q", name, w.path)
+	}
+	if _, err := w.tar.Write(data); err != nil {
+		return errors.Wrapf(err, "failed to write %q to the archive %q", name, w.path)
+	}
+	return nil
+}
+
+func (w *archiveWriter) close() error {
+	errList := []error{}
+	if err := w.tar.Close(); err != nil {
+		errList = append(errList, err)
+	}
+	if err := w.gzip.Close(); err != nil {
+		errList = append(errList, err)
+	}
+	if err := w.file.Close(); err != nil {
+		errList = append(errList, err)
+	}
+	if len(errList) > 0 {
+		return errors.Wrapf(kerrors.NewAggregate(errList), "failed to close the archive %q", w.path)
+	}
+	return nil
+}
+
+// readArchive reads all the Kubernetes objects saved in a gzip-compressed tarball, keyed by the name of the file they
+// were saved to; if required, the content of the archive is verified against the manifest, the same way verifyManifest
+// does for a directory.
+// Nb. The archive is read as a stream, one file at a time, computing the checksums on the fly.
+func readArchive(path string, verify bool) (map[string]unstructured.Unstructured, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open the source archive %q", path)
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the source archive %q", path)
+	}
+	defer gzipReader.Close()
+
+	objs := map[string]unstructured.Unstructured{}
+	got := map[string]string{}
+	var want map[string]string
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the source archive %q", path)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %q from the source archive %q", header.Name, path)
+		}
+
+		if header.Name == manifestFileName {
+			if want, err = parseManifest(data, header.Name); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if filepath.Ext(header.Name) != ".yaml" {
+			continue
+		}
+
+		fileObjs, err := utilyaml.ToUnstructured(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %q from the source archive %q", header.Name, path)
+		}
+		if len(fileObjs) != 1 {
+			return nil, errors.Errorf("expected exactly one object in %q from the source archive %q, found %d", header.Name, path, len(fileObjs))
+		}
+		objs[header.Name] = fileObjs[0]
+		got[header.Name] = dataChecksum(data)
+	}
+
+	if verify {
+		if want == nil {
+			return nil, errors.Errorf("failed to read the manifest from the source archive %q; the backup can't be verified", path)
+		}
+		if err := compareManifest(want, got); err != nil {
+			return nil, errors.Wrapf(err, "failed to verify the backup in %q", path)
+		}
+	}
+	return objs, nil
+}
+
+// archiveObjects returns the objects read from an archive, sorted by the name of the file they were saved to, the same
+// order objects are read from a directory.
+func archiveObjects(objs map[string]unstructured.Unstructured) []unstructured.Unstructured {
+	ret := make([]unstructured.Unstructured, 0, len(objs))
+	for _, fileName := range sets.StringKeySet(objs).List() {
+		ret = append(ret, objs[fileName])
+	}
+	return ret
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_objectMover_archive(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, err := ioutil.TempDir("", "clusterctl")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "backup.tar.gz")
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph := getObjectGraphWithObjs(test.NewFakeCluster("ns1", "foo").Objs())
+
+	discoveryTypes, err := getFakeDiscoveryTypes(graph)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(graph.Discovery("ns1", discoveryTypes)).To(Succeed())
+
+	// save the content of the source cluster to an archive
+	fromMover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	g.Expect(fromMover.save(graph, newArchiveWriter(path))).To(Succeed())
+
+	// rebuild the object graph from the archive, verifying it against the manifest
+	objs, err := readArchive(path, true)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(HaveLen(len(graph.uidToNode)))
+
+	restoredGraph := newObjectGraph(nil)
+	g.Expect(restoredGraph.addRestoredObjs(archiveObjects(objs))).To(Succeed())
+
+	// gets a fakeProxy to an empty cluster with all the required CRDs
+	toProxy := getFakeProxyWithCRDs()
+
+	// Run restore
+	mover := objectMover{
+		fromArchive: objs,
+	}
+	g.Expect(mover.restore(restoredGraph, toProxy)).To(Succeed())
+
+	// check that the objects are created in the target cluster
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for _, node := range graph.uidToNode {
+		key := client.ObjectKey{
+			Namespace: node.identity.Namespace,
+			Name:      node.identity.Name,
+		}
+
+		oTo := &unstructured.Unstructured{}
+		oTo.SetAPIVersion(node.identity.APIVersion)
+		oTo.SetKind(node.identity.Kind)
+		g.Expect(csTo.Get(ctx, key, oTo)).To(Succeed())
+	}
+}
+
+func Test_readArchive(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		verify  bool
+		wantErr string
+	}{
+		{
+			name: "Untouched backup",
+			files: map[string]string{
+				"secret_ns1_foo.yaml": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: foo\n  namespace: ns1\n",
+			},
+			verify: true,
+		},
+		{
+			name: "Changed file",
+			files: map[string]string{
+				"secret_ns1_foo.yaml": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: foo\n  namespace: ns2\n",
+			},
+			verify:  true,
+			wantErr: "checksum mismatch",
+		},
+		{
+			name: "Added file",
+			files: map[string]string{
+				"secret_ns1_foo.yaml": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: foo\n  namespace: ns1\n",
+				"secret_ns1_bar.yaml": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: bar\n  namespace: ns1\n",
+			},
+			verify:  true,
+			wantErr: "is not listed in the manifest",
+		},
+		{
+			name:    "Removed file",
+			files:   map[string]string{},
+			verify:  true,
+			wantErr: "is missing",
+		},
+		{
+			name: "Changed file without verifying",
+			files: map[string]string{
+				"secret_ns1_foo.yaml": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: foo\n  namespace: ns2\n",
+			},
+			verify: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			dir, err := ioutil.TempDir("", "clusterctl")
+			g.Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			// Write an archive where the manifest lists the original content of the files, and the files are possibly tampered.
+			original := "apiVersion: v1\nkind: Secret\nmetadata:\n  name: foo\n  namespace: ns1\n"
+			path := filepath.Join(dir, "backup.tar.gz")
+			w := newArchiveWriter(path)
+			g.Expect(w.open()).To(Succeed())
+			for name, data := range tt.files {
+				g.Expect(w.writeFile(name, []byte(data))).To(Succeed())
+			}
+			w.checksums = map[string]string{"secret_ns1_foo.yaml": dataChecksum([]byte(original))}
+			g.Expect(w.writeManifest([]string{"secret_ns1_foo.yaml"}, Kubeconfig{Path: "kubeconfig", Context: "mgmt"})).To(Succeed())
+			g.Expect(w.close()).To(Succeed())
+
+			objs, err := readArchive(path, tt.verify)
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(objs).To(HaveLen(len(tt.files)))
+		})
+	}
+}
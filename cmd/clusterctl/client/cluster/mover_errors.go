@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+var (
+	// ErrMissingProviders is matched by errors.Is when the providers installed in the source management cluster are
+	// missing in the target management cluster, or are older; see MissingProvidersError for the details.
+	ErrMissingProviders = errors.New("providers missing in the target management cluster")
+
+	// ErrDependencyCycle is matched by errors.Is when the owner references of the objects to be moved contain a cycle;
+	// see DependencyCycleError for the details.
+	ErrDependencyCycle = errors.New("cycle in the owner references")
+
+	// ErrTargetNotReady is matched by errors.Is when the providers in the target management cluster are not available
+	// before starting the move; see TargetNotReadyError for the details.
+	ErrTargetNotReady = errors.New("providers in the target management cluster not available")
+)
+
+// MissingProvidersError is returned by move when some providers installed in the source management cluster are not
+// installed in the target management cluster, or are installed with an older version.
+type MissingProvidersError struct {
+	// Providers lists the names of the providers missing in the target management cluster, or older than in the source.
+	Providers []string
+
+	errs []error
+}
+
+func (e *MissingProvidersError) Error() string {
+	return kerrors.NewAggregate(e.errs).Error()
+}
+
+// Is returns true if target is ErrMissingProviders.
+func (e *MissingProvidersError) Is(target error) bool {
+	return target == ErrMissingProviders
+}
+
+// DependencyCycleError is returned by move when the owner references of the objects to be moved contain a cycle, which
+// prevents the objects from being moved in order.
+type DependencyCycleError struct {
+	// Objects lists the objects in the cycle, each one owned by the next one; the last object is the same as the first one.
+	Objects []corev1.ObjectReference
+}
+
+func (e *DependencyCycleError) Error() string {
+	cycle := make([]string, 0, len(e.Objects))
+	for _, obj := range e.Objects {
+		cycle = append(cycle, objectRef(obj))
+	}
+	return fmt.Sprintf("found a cycle in the owner references, each object is owned by the next one: %s", strings.Join(cycle, " -> "))
+}
+
+// Is returns true if target is ErrDependencyCycle.
+func (e *DependencyCycleError) Is(target error) bool {
+	return target == ErrDependencyCycle
+}
+
+// TargetNotReadyError is returned by move when the Deployments of the providers in the target management cluster are
+// not available within the timeout.
+type TargetNotReadyError struct {
+	// Deployments lists the Deployments not available, in the namespace/name format followed by the available replicas.
+	Deployments []string
+
+	err error
+}
+
+func (e *TargetNotReadyError) Error() string {
+	return fmt.Sprintf("the providers in the target cluster are not available, Deployments not available: %s: %v", strings.Join(e.Deployments, ", "), e.err)
+}
+
+// Is returns true if target is ErrTargetNotReady.
+func (e *TargetNotReadyError) Is(target error) bool {
+	return target == ErrTargetNotReady
+}
+
+// Unwrap returns the error returned while waiting for the Deployments, e.g. a timeout.
+func (e *TargetNotReadyError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+const (
+	// eventReasonMoving is the reason of the events recorded on the Clusters in the source management cluster when a move starts.
+	eventReasonMoving = "Moving"
+
+	// eventReasonMoved is the reason of the events recorded on the Clusters in both the source and the target management
+	// cluster once all the objects are created in the target management cluster.
+	eventReasonMoved = "Moved"
+
+	// eventReasonCopying and eventReasonCopied replace eventReasonMoving and eventReasonMoved when copying, because the
+	// Clusters are left in the source management cluster.
+	eventReasonCopying = "Copying"
+	eventReasonCopied  = "Copied"
+
+	// eventSourceComponent is the component reported as the source of the events recorded by move.
+	eventSourceComponent = "clusterctl"
+)
+
+// recordClusterEvents records an event on each of the Clusters in the management cluster reachable via proxy, so users
+// watching the events, e.g. with kubectl get events, are informed of a move happening out-of-band.
+// Nb. Events are informational only, so failures, e.g. because creating events is forbidden, are logged and ignored.
+// Nb. Events are created directly instead of using a record.EventRecorder, because the recorder sends events asynchronously
+// through a broadcaster, so events recorded just before clusterctl exits could be dropped.
+func recordClusterEvents(ctx context.Context, proxy Proxy, clusters []*node, reason, message string) {
+	log := logf.Log
+
+	c, err := proxy.NewClient()
+	if err != nil {
+		log.V(1).Info("Failed to record events", "Reason", reason, "Error", err.Error())
+		return
+	}
+
+	now := metav1.Now()
+	for _, cluster := range clusters {
+		event := &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s.%x", cluster.identity.Name, now.UnixNano()),
+				Namespace: cluster.identity.Namespace,
+			},
+			InvolvedObject: cluster.identity,
+			Reason:         reason,
+			Message:        message,
+			Source:         corev1.EventSource{Component: eventSourceComponent},
+			FirstTimestamp: now,
+			LastTimestamp:  now,
+			Count:          1,
+			Type:           corev1.EventTypeNormal,
+		}
+		if err := c.Create(ctx, event); err != nil {
+			log.V(1).Info("Failed to record event", "Cluster", cluster.identity.Name, "Namespace", cluster.identity.Namespace, "Reason", reason, "Error", err.Error())
+		}
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+// MoveGraph is a read-only snapshot of the graph of the objects discovered by move, e.g. for tools planning or validating a
+// move operation; changing it has no effect on the move.
+type MoveGraph struct {
+	// Objects lists the objects that are going to be moved, sorted by kind, namespace and name.
+	Objects []MoveGraphObject `json:"objects"`
+
+	// Groups lists the objects in the order they are going to be moved; the objects in a group depend only on the objects
+	// in the previous groups, and are processed concurrently.
+	Groups [][]corev1.ObjectReference `json:"groups"`
+}
+
+// MoveGraphObject is an object in the move graph, together with its dependencies.
+type MoveGraphObject struct {
+	// Object identifies the object.
+	Object corev1.ObjectReference `json:"object"`
+
+	// Owners lists the objects owning the object through an OwnerReference.
+	Owners []corev1.ObjectReference `json:"owners,omitempty"`
+
+	// SoftOwners lists the objects owning the object through a naming convention, e.g. the Cluster of a kubeconfig Secret.
+	SoftOwners []corev1.ObjectReference `json:"softOwners,omitempty"`
+
+	// Clusters lists the Clusters the object belongs to, directly or through the chain of its owners.
+	Clusters []corev1.ObjectReference `json:"clusters,omitempty"`
+
+	// Virtual is true if the object is referenced by an OwnerReference, but was not found during the discovery.
+	Virtual bool `json:"virtual,omitempty"`
+}
+
+// newMoveGraph returns a snapshot of the objects that are going to be moved and of their dependencies.
+// Nb. Objects and references are sorted, so the snapshot is stable across runs.
+func newMoveGraph(graph *objectGraph) *MoveGraph {
+	nodes := graph.getNodesWithClusterTenants()
+	sortNodes(nodes)
+
+	ids := make(map[*node]string, len(nodes))
+	for i, n := range nodes {
+		ids[n] = fmt.Sprintf("n%d", i)
+	}
+
+	moveGraph := &MoveGraph{
+		Objects: []MoveGraphObject{},
+		Groups:  [][]corev1.ObjectReference{},
+	}
+	for _, n := range nodes {
+		clusters := []*node{}
+		for cluster := range n.tenantClusters {
+			clusters = append(clusters, cluster)
+		}
+		sortNodes(clusters)
+
+		moveGraph.Objects = append(moveGraph.Objects, MoveGraphObject{
+			Object:     n.identity,
+			Owners:     nodeIdentities(sortedOwners(n.owners, ids)),
+			SoftOwners: nodeIdentities(sortedSoftOwners(n.softOwners, ids)),
+			Clusters:   nodeIdentities(clusters),
+			Virtual:    n.virtual,
+		})
+	}
+	for _, group := range getMoveSequence(graph).groups {
+		groupNodes := append([]*node{}, group...)
+		sortNodes(groupNodes)
+		moveGraph.Groups = append(moveGraph.Groups, nodeIdentities(groupNodes))
+	}
+	return moveGraph
+}
+
+// nodeIdentities returns the identities of a list of nodes, or nil if the list is empty.
+func nodeIdentities(nodes []*node) []corev1.ObjectReference {
+	if len(nodes) == 0 {
+		return nil
+	}
+	identities := make([]corev1.ObjectReference, 0, len(nodes))
+	for _, n := range nodes {
+		identities = append(identities, n.identity)
+	}
+	return identities
+}
+
+// writeGraph writes the objects that are going to be moved and their dependencies to a file in the Graphviz DOT format.
+func writeGraph(graph *objectGraph, path string) error {
+	log := logf.Log
+	log.Info("Writing the object graph", "File", path)
+
+	if err := ioutil.WriteFile(path, graphToDOT(graph), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write the object graph to %q", path)
+	}
+	return nil
+}
+
+// graphToDOT renders the objects that are going to be moved in the Graphviz DOT format; each object is a node labeled with its
+// kind and name, and each dependency is an edge from the owner to the owned object. Soft ownership, e.g. Secrets linked to a
+// Cluster by a naming convention, is rendered as a dashed edge.
+// Nb. Nodes and edges are sorted, so the output is stable across runs.
+func graphToDOT(graph *objectGraph) []byte {
+	nodes := graph.getNodesWithClusterTenants()
+	sortNodes(nodes)
+
+	ids := make(map[*node]string, len(nodes))
+	for i, n := range nodes {
+		ids[n] = fmt.Sprintf("n%d", i)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("digraph move {\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, n := range nodes {
+		name := n.identity.Name
+		if n.identity.Namespace != "" {
+			name = n.identity.Namespace + "/" + name
+		}
+		fmt.Fprintf(&b, "  %s [label=%q];\n", ids[n], n.identity.GroupVersionKind().String()+"\n"+name)
+	}
+	for _, n := range nodes {
+		for _, owner := range sortedOwners(n.owners, ids) {
+			fmt.Fprintf(&b, "  %s -> %s;\n", ids[owner], ids[n])
+		}
+		for _, owner := range sortedSoftOwners(n.softOwners, ids) {
+			fmt.Fprintf(&b, "  %s -> %s [style=dashed];\n", ids[owner], ids[n])
+		}
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+// sortedOwners returns the owners included in the graph rendering, sorted by the order of the rendered nodes.
+func sortedOwners(owners map[*node]ownerReferenceAttributes, ids map[*node]string) []*node {
+	nodes := []*node{}
+	for owner := range owners {
+		if _, ok := ids[owner]; ok {
+			nodes = append(nodes, owner)
+		}
+	}
+	sortNodes(nodes)
+	return nodes
+}
+
+// sortedSoftOwners returns the soft owners included in the graph rendering, sorted by the order of the rendered nodes.
+func sortedSoftOwners(owners map[*node]empty, ids map[*node]string) []*node {
+	nodes := []*node{}
+	for owner := range owners {
+		if _, ok := ids[owner]; ok {
+			nodes = append(nodes, owner)
+		}
+	}
+	sortNodes(nodes)
+	return nodes
+}
+
+// sortNodes sorts nodes by kind, namespace and name.
+func sortNodes(nodes []*node) {
+	sort.Slice(nodes, func(i, j int) bool {
+		gvkI, gvkJ := nodes[i].identity.GroupVersionKind().String(), nodes[j].identity.GroupVersionKind().String()
+		if gvkI != gvkJ {
+			return gvkI < gvkJ
+		}
+		if nodes[i].identity.Namespace != nodes[j].identity.Namespace {
+			return nodes[i].identity.Namespace < nodes[j].identity.Namespace
+		}
+		return nodes[i].identity.Name < nodes[j].identity.Name
+	})
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_graphToDOT(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph := getObjectGraphWithObjs(test.NewFakeCluster("ns1", "foo").Objs())
+
+	// Get all the types to be considered for discovery
+	discoveryTypes, err := getFakeDiscoveryTypes(graph)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// trigger discovery the content of the source cluster
+	g.Expect(graph.Discovery("ns1", discoveryTypes)).To(Succeed())
+
+	g.Expect(string(graphToDOT(graph))).To(Equal(`digraph move {
+  node [shape=box];
+  n0 [label="/v1, Kind=Secret\nns1/foo-ca"];
+  n1 [label="/v1, Kind=Secret\nns1/foo-kubeconfig"];
+  n2 [label="cluster.x-k8s.io/v1alpha3, Kind=Cluster\nns1/foo"];
+  n3 [label="infrastructure.cluster.x-k8s.io/v1alpha3, Kind=DummyInfrastructureCluster\nns1/foo"];
+  n2 -> n0 [style=dashed];
+  n2 -> n1;
+  n2 -> n3;
+}
+`))
+}
+
+func Test_newMoveGraph(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph := getObjectGraphWithObjs(test.NewFakeCluster("ns1", "foo").Objs())
+
+	// Get all the types to be considered for discovery
+	discoveryTypes, err := getFakeDiscoveryTypes(graph)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// trigger discovery the content of the source cluster
+	g.Expect(graph.Discovery("ns1", discoveryTypes)).To(Succeed())
+
+	moveGraph := newMoveGraph(graph)
+
+	ref := func(kind, name string) corev1.ObjectReference {
+		for _, n := range graph.uidToNode {
+			if n.identity.Kind == kind && n.identity.Name == name {
+				return n.identity
+			}
+		}
+		t.Fatalf("%s %s not found in the graph", kind, name)
+		return corev1.ObjectReference{}
+	}
+	cluster := ref("Cluster", "foo")
+	infraCluster := ref("DummyInfrastructureCluster", "foo")
+	caSecret := ref("Secret", "foo-ca")
+	kubeconfigSecret := ref("Secret", "foo-kubeconfig")
+
+	g.Expect(moveGraph.Objects).To(Equal([]MoveGraphObject{
+		{Object: caSecret, SoftOwners: []corev1.ObjectReference{cluster}, Clusters: []corev1.ObjectReference{cluster}},
+		{Object: kubeconfigSecret, Owners: []corev1.ObjectReference{cluster}, Clusters: []corev1.ObjectReference{cluster}},
+		{Object: cluster, Clusters: []corev1.ObjectReference{cluster}},
+		{Object: infraCluster, Owners: []corev1.ObjectReference{cluster}, Clusters: []corev1.ObjectReference{cluster}},
+	}))
+	g.Expect(moveGraph.Groups).To(Equal([][]corev1.ObjectReference{
+		{cluster},
+		{caSecret, kubeconfigSecret, infraCluster},
+	}))
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/cluster-api/cmd/version"
+)
+
+// manifestFileName is the name of the file listing the checksum of all the files saved to a directory.
+const manifestFileName = "manifest.sha256"
+
+// writeManifest writes to a directory the manifest listing the SHA-256 checksum of the given files, in the same format
+// used by sha256sum, so the content of the directory can be verified before restoring it, also using standard tools.
+func writeManifest(directory string, fileNames []string, source Kubeconfig) error {
+	checksums := map[string]string{}
+	for _, fileName := range fileNames {
+		checksum, err := fileChecksum(filepath.Join(directory, fileName))
+		if err != nil {
+			return err
+		}
+		checksums[fileName] = checksum
+	}
+
+	path := filepath.Join(directory, manifestFileName)
+	if err := ioutil.WriteFile(path, formatManifest(checksums, source), 0600); err != nil {
+		return errors.Wrapf(err, "failed to write the manifest %q", path)
+	}
+	return nil
+}
+
+// formatManifest returns a manifest listing the given checksums, keyed by file name, sorted by file name.
+// Metadata about the backup, e.g. the clusterctl version, is added as comment lines at the top of the manifest.
+func formatManifest(checksums map[string]string, source Kubeconfig) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# clusterctl-version: %s\n", version.Get().GitVersion)
+	fmt.Fprintf(&b, "# source-kubeconfig: %s\n", source.Path)
+	fmt.Fprintf(&b, "# source-context: %s\n", source.Context)
+	fmt.Fprintf(&b, "# timestamp: %s\n", time.Now().UTC().Format(time.RFC3339))
+
+	for _, fileName := range sets.StringKeySet(checksums).List() {
+		fmt.Fprintf(&b, "%s  %s\n", checksums[fileName], fileName)
+	}
+	return b.Bytes()
+}
+
+// verifyManifest checks that all the YAML files in a directory are listed in the manifest with the same checksum, and
+// that no file listed in the manifest is missing, so tampered or partial backups are detected before restoring them.
+func verifyManifest(directory string) error {
+	path := filepath.Join(directory, manifestFileName)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the manifest %q; the backup can't be verified", path)
+	}
+
+	want, err := parseManifest(data, path)
+	if err != nil {
+		return err
+	}
+
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the source directory %q", directory)
+	}
+
+	got := map[string]string{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".yaml" {
+			continue
+		}
+		checksum, err := fileChecksum(filepath.Join(directory, file.Name()))
+		if err != nil {
+			return err
+		}
+		got[file.Name()] = checksum
+	}
+
+	if err := compareManifest(want, got); err != nil {
+		return errors.Wrapf(err, "failed to verify the backup in %q", directory)
+	}
+	return nil
+}
+
+// parseManifest returns the checksums listed in a manifest, keyed by file name.
+func parseManifest(data []byte, path string) (map[string]string, error) {
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, errors.Errorf("invalid line %q in the manifest %q", line, path)
+		}
+		checksums[fields[1]] = fields[0]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read the manifest %q", path)
+	}
+	return checksums, nil
+}
+
+// compareManifest compares the checksums listed in a manifest with the checksums of the files found in a backup, both keyed
+// by file name, returning an error listing the files not listed in the manifest, changed or missing, if any.
+func compareManifest(want, got map[string]string) error {
+	errList := []error{}
+	for _, fileName := range sets.StringKeySet(got).List() {
+		checksum, ok := want[fileName]
+		if !ok {
+			errList = append(errList, errors.Errorf("file %q is not listed in the manifest", fileName))
+			continue
+		}
+		if got[fileName] != checksum {
+			errList = append(errList, errors.Errorf("checksum mismatch for file %q", fileName))
+		}
+	}
+	for _, fileName := range sets.StringKeySet(want).List() {
+		if _, ok := got[fileName]; !ok {
+			errList = append(errList, errors.Errorf("file %q listed in the manifest is missing", fileName))
+		}
+	}
+	return kerrors.NewAggregate(errList)
+}
+
+// fileChecksum returns the hex-encoded SHA-256 checksum of a file.
+func fileChecksum(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read %q", path)
+	}
+	return dataChecksum(data), nil
+}
+
+// dataChecksum returns the hex-encoded SHA-256 checksum of the content of a file.
+func dataChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
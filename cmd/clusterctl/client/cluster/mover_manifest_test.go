@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_verifyManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		tamper  func(dir string) error
+		wantErr string
+	}{
+		{
+			name:    "Untouched backup",
+			tamper:  func(dir string) error { return nil },
+			wantErr: "",
+		},
+		{
+			name: "Changed file",
+			tamper: func(dir string) error {
+				return ioutil.WriteFile(filepath.Join(dir, "cluster.cluster.x-k8s.io_ns1_foo.yaml"), []byte("changed"), 0600)
+			},
+			wantErr: "checksum mismatch",
+		},
+		{
+			name: "Added file",
+			tamper: func(dir string) error {
+				return ioutil.WriteFile(filepath.Join(dir, "secret_ns1_bar.yaml"), []byte("added"), 0600)
+			},
+			wantErr: "is not listed in the manifest",
+		},
+		{
+			name: "Removed file",
+			tamper: func(dir string) error {
+				return os.Remove(filepath.Join(dir, "secret_ns1_foo-kubeconfig.yaml"))
+			},
+			wantErr: "is missing",
+		},
+		{
+			name: "Missing manifest",
+			tamper: func(dir string) error {
+				return os.Remove(filepath.Join(dir, manifestFileName))
+			},
+			wantErr: "the backup can't be verified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			dir, err := ioutil.TempDir("", "clusterctl")
+			g.Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			fileNames := []string{"cluster.cluster.x-k8s.io_ns1_foo.yaml", "secret_ns1_foo-kubeconfig.yaml"}
+			for _, fileName := range fileNames {
+				g.Expect(ioutil.WriteFile(filepath.Join(dir, fileName), []byte(fileName), 0600)).To(Succeed())
+			}
+			g.Expect(writeManifest(dir, fileNames, Kubeconfig{Path: "kubeconfig", Context: "mgmt"})).To(Succeed())
+
+			manifest, err := ioutil.ReadFile(filepath.Join(dir, manifestFileName))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(manifest)).To(ContainSubstring("# source-context: mgmt\n"))
+
+			g.Expect(tt.tamper(dir)).To(Succeed())
+
+			err = verifyManifest(dir)
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+// validateRenameClusters checks that the Clusters are renamed to legal and distinct names.
+// Nb. The new name is used as a value of the cluster.x-k8s.io/cluster-name label too, so it must be a valid label value.
+func validateRenameClusters(options MoveOptions) error {
+	renamedTo := map[string]string{}
+	for from, to := range options.RenameClusters {
+		if from == "" || from == to {
+			return errors.Errorf("invalid rename of the Cluster %q to %q", from, to)
+		}
+		if errs := append(validation.IsDNS1123Subdomain(to), validation.IsValidLabelValue(to)...); len(errs) > 0 {
+			return errors.Errorf("invalid name %q for the Cluster %q: %s", to, from, strings.Join(errs, "; "))
+		}
+		if other, ok := renamedTo[to]; ok {
+			return errors.Errorf("the Clusters %q and %q can't be both renamed to %q", other, from, to)
+		}
+		renamedTo[to] = from
+	}
+	return nil
+}
+
+// setRenamedObjects computes the names in the target management cluster of the Clusters renamed by renameClusters and of the
+// Secrets following the Cluster API naming convention for the Secrets of a Cluster, e.g. <cluster-name>-kubeconfig, so the
+// object graph stays consistent once renamed.
+// An error is returned if a Cluster to be renamed is not going to be moved, or if its new name is already used by another Cluster.
+func (o *objectMover) setRenamedObjects(graph *objectGraph) error {
+	o.renamedObjects = map[types.UID]string{}
+	o.renamedClusters = map[types.NamespacedName]string{}
+	o.renamedSecrets = map[types.NamespacedName]string{}
+	if len(o.renameClusters) == 0 {
+		return nil
+	}
+
+	clusters := graph.getClusters()
+	existing := map[types.NamespacedName]bool{}
+	for _, cluster := range clusters {
+		existing[types.NamespacedName{Namespace: cluster.identity.Namespace, Name: cluster.identity.Name}] = true
+	}
+
+	for from, to := range o.renameClusters {
+		found := false
+		for _, cluster := range clusters {
+			if cluster.identity.Name != from {
+				continue
+			}
+			found = true
+
+			if _, ok := o.renameClusters[to]; existing[types.NamespacedName{Namespace: cluster.identity.Namespace, Name: to}] && !ok {
+				return errors.Errorf("the Cluster %s/%s can't be renamed to %q, because a Cluster with the same name already exists", cluster.identity.Namespace, from, to)
+			}
+			o.renamedObjects[cluster.identity.UID] = to
+			o.renamedClusters[types.NamespacedName{Namespace: cluster.identity.Namespace, Name: from}] = to
+
+			for _, secret := range graph.getSecrets() {
+				if _, ok := secret.tenantClusters[cluster]; !ok || !strings.HasPrefix(secret.identity.Name, from+"-") {
+					continue
+				}
+				name := to + strings.TrimPrefix(secret.identity.Name, from)
+				o.renamedObjects[secret.identity.UID] = name
+				o.renamedSecrets[types.NamespacedName{Namespace: secret.identity.Namespace, Name: secret.identity.Name}] = name
+			}
+		}
+		if !found {
+			return errors.Errorf("the Cluster %q to be renamed is not going to be moved", from)
+		}
+	}
+	return nil
+}
+
+// targetName returns the name of the object corresponding to a node in the target management cluster, if renamed.
+func (o *objectMover) targetName(n *node) string {
+	if name, ok := o.renamedObjects[n.identity.UID]; ok {
+		return name
+	}
+	return n.identity.Name
+}
+
+// renameObject renames an object, if required, and rewrites the references to the renamed Clusters and Secrets, i.e. the
+// cluster.x-k8s.io/cluster-name labels, including the ones in label selectors, the clusterName fields and the references
+// to the renamed objects by kind and name.
+// Nb. References are rewritten only in the objects in the same namespace of the renamed objects, because names are unique only
+// within a namespace; cluster-scoped objects are left untouched.
+func (o *objectMover) renameObject(n *node, obj *unstructured.Unstructured) {
+	if len(o.renamedObjects) == 0 {
+		return
+	}
+	namespace := n.identity.Namespace
+	renameReferences(obj.Object, "", renamesInNamespace(o.renamedClusters, namespace), renamesInNamespace(o.renamedSecrets, namespace))
+	obj.SetName(o.targetName(n))
+}
+
+// renamesInNamespace returns the target names of the renamed objects in a namespace, by source name.
+func renamesInNamespace(renamed map[types.NamespacedName]string, namespace string) map[string]string {
+	names := map[string]string{}
+	if namespace == "" {
+		return names
+	}
+	for key, to := range renamed {
+		if key.Namespace == namespace {
+			names[key.Name] = to
+		}
+	}
+	return names
+}
+
+// renameReferences rewrites all the references to the renamed Clusters and Secrets nested in value, which is the value of
+// the given field.
+// Nb. References to Secrets without kind are rewritten only in fields named after a Secret reference, e.g. secretRef.
+func renameReferences(value interface{}, field string, clusters, secrets map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, nameField := range []string{clusterv1.ClusterLabelName, "clusterName"} {
+			if name, ok := v[nameField].(string); ok {
+				if to, ok := clusters[name]; ok {
+					v[nameField] = to
+				}
+			}
+		}
+		if name, ok := v["name"].(string); ok {
+			kind, _ := v["kind"].(string)
+			if to, ok := clusters[name]; ok && kind == "Cluster" {
+				v["name"] = to
+			}
+			isSecretRef := kind == "Secret" || (kind == "" && strings.HasSuffix(strings.ToLower(field), "secretref"))
+			if to, ok := secrets[name]; ok && isSecretRef {
+				v["name"] = to
+			}
+		}
+		for nestedField, nested := range v {
+			renameReferences(nested, nestedField, clusters, secrets)
+		}
+	case []interface{}:
+		for _, nested := range v {
+			renameReferences(nested, field, clusters, secrets)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// moveState records the progress of a move operation in a state file, so an interrupted move can be resumed
+// without creating twice the objects already created in the target management cluster or trying to delete
+// objects already deleted from the source management cluster.
+type moveState struct {
+	path string
+	lock sync.Mutex
+
+	// Created maps the UID of the objects in the source management cluster to the UID of the
+	// corresponding objects already created in the target management cluster.
+	Created map[types.UID]types.UID `json:"created,omitempty"`
+
+	// Deleted records the UID of the objects already deleted from the source management cluster.
+	Deleted map[types.UID]bool `json:"deleted,omitempty"`
+
+	// Paused records the UID of the Clusters that were already paused in the source management cluster before the move
+	// paused them.
+	Paused map[types.UID]bool `json:"paused,omitempty"`
+
+	// resumed is set when the moveState is read from the state file of an interrupted move operation.
+	resumed bool
+}
+
+// newMoveState returns a moveState for a new move operation, failing if the state file already exists,
+// because this means a previous move operation was interrupted and should be resumed instead.
+// The state file is written only when the first progress is recorded, i.e. just before the source Clusters are paused,
+// so a move failing the preflight checks does not leave a state file behind.
+func newMoveState(path string) (*moveState, error) {
+	if _, err := os.Stat(path); err == nil {
+		return nil, errors.Errorf("state file %q already exists; resume the interrupted move or delete the state file", path)
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "failed to check state file %q", path)
+	}
+
+	s := &moveState{
+		path:    path,
+		Created: map[types.UID]types.UID{},
+		Deleted: map[types.UID]bool{},
+		Paused:  map[types.UID]bool{},
+	}
+	return s, nil
+}
+
+// readMoveState reads the moveState of an interrupted move operation from the state file.
+func readMoveState(path string) (*moveState, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read state file %q", path)
+	}
+
+	s := &moveState{}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse state file %q", path)
+	}
+	s.path = path
+	s.resumed = true
+	if s.Created == nil {
+		s.Created = map[types.UID]types.UID{}
+	}
+	if s.Deleted == nil {
+		s.Deleted = map[types.UID]bool{}
+	}
+	if s.Paused == nil {
+		s.Paused = map[types.UID]bool{}
+	}
+	return s, nil
+}
+
+// getCreated returns the UID of the object created in the target management cluster for a source object, if any.
+func (s *moveState) getCreated(sourceUID types.UID) (types.UID, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	newUID, ok := s.Created[sourceUID]
+	return newUID, ok
+}
+
+// setCreated records that an object was created in the target management cluster.
+func (s *moveState) setCreated(sourceUID, newUID types.UID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.Created[sourceUID] = newUID
+	return s.save()
+}
+
+// isDeleted returns true if a source object was already deleted from the source management cluster.
+func (s *moveState) isDeleted(sourceUID types.UID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.Deleted[sourceUID]
+}
+
+// setDeleted records that an object was deleted from the source management cluster.
+func (s *moveState) setDeleted(sourceUID types.UID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.Deleted[sourceUID] = true
+	return s.save()
+}
+
+// syncPaused records which Clusters were already paused before the move pauses them; when resuming an interrupted move
+// all the Clusters are paused by then, so their original state is read back from the state file instead.
+func (s *moveState) syncPaused(clusters []*node) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.resumed {
+		for _, cluster := range clusters {
+			cluster.paused = s.Paused[cluster.identity.UID]
+		}
+		return nil
+	}
+
+	for _, cluster := range clusters {
+		if cluster.paused {
+			s.Paused[cluster.identity.UID] = true
+		}
+	}
+	return s.save()
+}
+
+// save writes the moveState to the state file; the file is replaced atomically, so it is never left half-written
+// if the move operation is interrupted.
+func (s *moveState) save() error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the move state")
+	}
+
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write state file %q", s.path)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return errors.Wrapf(err, "failed to write state file %q", s.path)
+	}
+	return nil
+}
+
+// remove deletes the state file once the move operation is completed.
+func (s *moveState) remove() error {
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to delete state file %q", s.path)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_moveState(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, err := ioutil.TempDir("", "cluster-client")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "state.yaml")
+
+	// Reading a state file that does not exist fails.
+	_, err = readMoveState(path)
+	g.Expect(err).To(HaveOccurred())
+
+	// Records the progress of a move.
+	state, err := newMoveState(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = os.Stat(path)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+	g.Expect(state.setCreated("source-uid-1", "target-uid-1")).To(Succeed())
+	g.Expect(state.setCreated("source-uid-2", "target-uid-2")).To(Succeed())
+	g.Expect(state.setDeleted("source-uid-2")).To(Succeed())
+
+	// Records the Clusters that were paused before the move.
+	paused := &node{identity: corev1.ObjectReference{UID: "source-uid-1"}, paused: true}
+	active := &node{identity: corev1.ObjectReference{UID: "source-uid-2"}}
+	g.Expect(state.syncPaused([]*node{paused, active})).To(Succeed())
+
+	// A new move fails if the state file of an interrupted move exists.
+	_, err = newMoveState(path)
+	g.Expect(err).To(HaveOccurred())
+
+	// The progress is read back when resuming.
+	resumed, err := readMoveState(path)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	newUID, ok := resumed.getCreated("source-uid-1")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(newUID).To(BeEquivalentTo("target-uid-1"))
+	g.Expect(resumed.isDeleted("source-uid-1")).To(BeFalse())
+
+	newUID, ok = resumed.getCreated("source-uid-2")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(newUID).To(BeEquivalentTo("target-uid-2"))
+	g.Expect(resumed.isDeleted("source-uid-2")).To(BeTrue())
+
+	_, ok = resumed.getCreated("source-uid-3")
+	g.Expect(ok).To(BeFalse())
+
+	// When resuming, the Clusters are all paused, so their original state is read back from the state file.
+	paused.paused, active.paused = true, true
+	g.Expect(resumed.syncPaused([]*node{paused, active})).To(Succeed())
+	g.Expect(paused.paused).To(BeTrue())
+	g.Expect(active.paused).To(BeFalse())
+
+	// The state file is deleted once the move completes.
+	g.Expect(resumed.remove()).To(Succeed())
+	_, err = os.Stat(path)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+// MoveSummary reports the outcome of a move operation.
+type MoveSummary struct {
+	// Total is the total number of objects moved.
+	Total int `json:"total"`
+
+	// Objects reports the number of objects moved for each kind.
+	Objects map[string]int `json:"objects,omitempty"`
+
+	// Phases lists the phases of the move operation in the order they were executed.
+	Phases []MovePhase `json:"phases,omitempty"`
+
+	// Moved lists the objects created in the target management cluster.
+	Moved []MoveObject `json:"moved,omitempty"`
+
+	// Skipped lists the objects not created in the target management cluster, because they were already existing there
+	// or already created by an interrupted move.
+	Skipped []MoveObject `json:"skipped,omitempty"`
+
+	// Unchanged is the number of objects not synced, when syncing with Since set, because not modified since then.
+	Unchanged int `json:"unchanged,omitempty"`
+
+	// Failed lists the objects that could not be created in the target management cluster or deleted from the source
+	// management cluster, together with the error.
+	Failed []MoveObject `json:"failed,omitempty"`
+
+	// Diff lists, when syncing with Diff set, how each object would be changed in the target management cluster.
+	Diff []ObjectDiff `json:"diff,omitempty"`
+
+	// SkippedKinds lists the kinds skipped during the discovery because not served by the source management cluster,
+	// e.g. because a provider was partially uninstalled; the objects of these kinds are not moved.
+	SkippedKinds []string `json:"skippedKinds,omitempty"`
+
+	// Discovered lists, when only listing the objects, the objects that would be moved.
+	Discovered []MoveObject `json:"discovered,omitempty"`
+
+	// Drift lists, when VerifyObjects is set, the objects that differ, once read back from the target management cluster,
+	// from the objects move created or updated, e.g. because of a defaulting or mutating webhook.
+	Drift []ObjectDiff `json:"drift,omitempty"`
+}
+
+// MoveObject identifies an object processed by a move operation.
+type MoveObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+
+	// Error reports why processing the object failed, if this is the case.
+	Error string `json:"error,omitempty"`
+}
+
+// ObjectDiff reports how an object would be changed in the target management cluster by a sync, or how an object created
+// or updated by move was changed by the target management cluster.
+type ObjectDiff struct {
+	MoveObject
+
+	// Operation is the operation a sync would perform on the object, i.e. create, update or unchanged; for drift, it is
+	// the operation move performed, i.e. create or update.
+	Operation string `json:"operation"`
+
+	// Patch is the JSON merge patch a sync would apply to the existing object, if updated; for drift, it is the JSON merge
+	// patch turning the object move created or updated into the object read back from the target management cluster.
+	Patch string `json:"patch,omitempty"`
+}
+
+// MovePhase reports the duration of a phase of the move operation.
+type MovePhase struct {
+	// Name of the phase, e.g. creating objects in the target cluster.
+	Name string `json:"name"`
+
+	// Duration of the phase.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// setObjects records in the summary all the objects included in a move sequence.
+func (s *MoveSummary) setObjects(moveSequence *moveSequence) {
+	s.Total = 0
+	s.Objects = map[string]int{}
+	for _, group := range moveSequence.groups {
+		for _, n := range group {
+			s.Total++
+			s.Objects[n.identity.Kind]++
+		}
+	}
+}
+
+// recordObject adds the object corresponding to a node to one of the object lists of the move summary.
+// Nb. Objects in the same moveGroup are processed concurrently, so access to the summary is serialized.
+func (o *objectMover) recordObject(list *[]MoveObject, n *node, err error) {
+	object := MoveObject{
+		APIVersion: n.identity.APIVersion,
+		Kind:       n.identity.Kind,
+		Namespace:  n.identity.Namespace,
+		Name:       n.identity.Name,
+	}
+	if err != nil {
+		object.Error = err.Error()
+	}
+
+	o.summaryLock.Lock()
+	defer o.summaryLock.Unlock()
+	*list = append(*list, object)
+}
+
+// recordUnchanged records in the move summary the number of objects not synced because not modified.
+func (o *objectMover) recordUnchanged(count int) {
+	o.summaryLock.Lock()
+	defer o.summaryLock.Unlock()
+	o.summary.Unchanged += count
+}
+
+// runPhase runs a phase of the move operation, recording its duration in the move summary.
+func (o *objectMover) runPhase(phase string, f func() error) error {
+	start := time.Now()
+	err := f()
+	o.summary.Phases = append(o.summary.Phases, MovePhase{
+		Name:     phase,
+		Duration: metav1.Duration{Duration: time.Since(start)},
+	})
+	return o.wrapTimeout(err, phase)
+}
+
+// logGroupProgress logs how many objects of each kind are processed in a moveGroup.
+func logGroupProgress(action string, groupIndex, groups int, group moveGroup) {
+	kinds := map[string]int{}
+	for _, n := range group {
+		kinds[n.identity.Kind]++
+	}
+
+	counts := make([]string, 0, len(kinds))
+	for kind, count := range kinds {
+		counts = append(counts, fmt.Sprintf("%s=%d", kind, count))
+	}
+	sort.Strings(counts)
+
+	log := logf.Log
+	log.Info(action, "Step", fmt.Sprintf("%d/%d", groupIndex+1, groups), "Objects", strings.Join(counts, ", "))
+}
+
+// logObjectOperation logs, at high verbosity (-v 5), the outcome of an operation on an object, i.e. the resulting UID and
+// resource version or the error returned by the API server, so the move can be audited object by object.
+func logObjectOperation(operation string, obj *unstructured.Unstructured, err error) {
+	log := logf.Log.V(5)
+	keysAndValues := append(logf.UnstructuredToValues(*obj), "APIVersion", obj.GetAPIVersion())
+	if err != nil {
+		log.Info(operation+" failed", append(keysAndValues, "Error", err.Error())...)
+		return
+	}
+	log.Info(operation+" succeeded", append(keysAndValues, "UID", string(obj.GetUID()), "ResourceVersion", obj.GetResourceVersion())...)
+}
+
+// logSummary logs the summary of a move operation.
+func logSummary(summary *MoveSummary) {
+	log := logf.Log
+	log.Info("Move completed", "Objects", summary.Total)
+	if len(summary.Skipped) > 0 {
+		log.Info("Objects skipped", "Objects", len(summary.Skipped))
+	}
+	if summary.Unchanged > 0 {
+		log.Info("Objects not modified skipped", "Objects", summary.Unchanged)
+	}
+	if len(summary.SkippedKinds) > 0 {
+		log.Info("Warning: kinds not served by the source cluster skipped", "Kinds", strings.Join(summary.SkippedKinds, ", "))
+	}
+	for _, phase := range summary.Phases {
+		log.Info("Phase completed", "Phase", phase.Name, "Duration", phase.Duration.Round(time.Millisecond).String())
+	}
+}
@@ -17,18 +17,34 @@ limitations under the License.
 package cluster
 
 import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	utilyaml "sigs.k8s.io/cluster-api/cmd/clusterctl/internal/util"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -365,100 +381,2357 @@ func Test_getMoveSequence(t *testing.T) {
 	for _, tt := range moveTests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
-			graph := getObjectGraphWithObjs(tt.fields.objs)
+			graph, _ := discoverFakeGraph(t, tt.fields.objs)
 
-			// Get all the types to be considered for discovery
-			discoveryTypes, err := getFakeDiscoveryTypes(graph)
+			moveSequence := getMoveSequence(graph)
+			g.Expect(moveSequence.groups).To(HaveLen(len(tt.wantMoveGroups)))
+
+			for i, gotGroup := range moveSequence.groups {
+				wantGroup := tt.wantMoveGroups[i]
+				gotNodes := []string{}
+				for _, node := range gotGroup {
+					gotNodes = append(gotNodes, string(node.identity.UID))
+				}
+
+				g.Expect(gotNodes).To(ConsistOf(wantGroup))
+			}
+		})
+	}
+}
+
+func Test_objectMover_move(t *testing.T) {
+	g := NewWithT(t)
+	// NB. we are testing the move and move sequence using the same set of moveTests, but checking the results at different stages of the move process
+	for _, tt := range moveTests {
+		for _, parallelism := range []int{1, 4} {
+			t.Run(fmt.Sprintf("%s (parallelism %d)", tt.name, parallelism), func(t *testing.T) {
+				// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+				graph, toProxy := discoverFakeGraph(t, tt.fields.objs)
+
+				// Run move
+				mover := objectMover{
+					fromProxy:   graph.proxy,
+					parallelism: parallelism,
+				}
+
+				err := mover.move(graph, toProxy)
+				if tt.wantErr {
+					g.Expect(err).To(HaveOccurred())
+					return
+				}
+
+				g.Expect(err).NotTo(HaveOccurred())
+
+				// check that the summary reports all the objects moved and all the phases of the move
+				g.Expect(mover.summary.Total).To(Equal(len(graph.getNodesWithClusterTenants())))
+				phases := []string{}
+				for _, phase := range mover.summary.Phases {
+					phases = append(phases, phase.Name)
+				}
+				g.Expect(phases).To(Equal([]string{
+					"pausing the source cluster",
+					"creating target namespaces",
+					"creating objects in the target cluster",
+					"deleting objects from the source cluster",
+					"waiting for objects to be deleted from the source cluster",
+					"resuming the target cluster",
+				}))
+
+				// check that the objects are removed from the source cluster and are created in the target cluster
+				csFrom, err := graph.proxy.NewClient()
+				g.Expect(err).NotTo(HaveOccurred())
+
+				csTo, err := toProxy.NewClient()
+				g.Expect(err).NotTo(HaveOccurred())
+
+				for _, node := range graph.uidToNode {
+					key := client.ObjectKey{
+						Namespace: node.identity.Namespace,
+						Name:      node.identity.Name,
+					}
+
+					// objects are deleted from the source cluster
+					oFrom := &unstructured.Unstructured{}
+					oFrom.SetAPIVersion(node.identity.APIVersion)
+					oFrom.SetKind(node.identity.Kind)
+
+					err := csFrom.Get(ctx, key, oFrom)
+					if err == nil {
+						t.Errorf("%v not deleted in source cluster", key)
+						continue
+					}
+					if !apierrors.IsNotFound(err) {
+						t.Errorf("error = %v when checking for %v deleted in source cluster", err, key)
+						continue
+					}
+
+					// objects are created in the target cluster
+					oTo := &unstructured.Unstructured{}
+					oTo.SetAPIVersion(node.identity.APIVersion)
+					oTo.SetKind(node.identity.Kind)
+
+					if err := csTo.Get(ctx, key, oTo); err != nil {
+						t.Errorf("error = %v when checking for %v created in target cluster", err, key)
+						continue
+					}
+				}
+			})
+		}
+	}
+}
+
+func Test_objectMover_move_resume(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, err := ioutil.TempDir("", "cluster-client")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(dir)
+
+	stateFile := filepath.Join(dir, "state.yaml")
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph, toProxy := discoverFakeGraph(t, test.NewFakeCluster("ns1", "foo").WithMachines(
+		test.NewFakeMachine("m1"),
+		test.NewFakeMachine("m2"),
+	).Objs())
+
+	// Simulates a move interrupted after creating the first group of objects in the target cluster.
+	mover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	g.Expect(mover.setState(MoveOptions{StateFile: stateFile})).To(Succeed())
+	g.Expect(mover.ensureNamespaces(graph, toProxy)).To(Succeed())
+
+	firstGroup := getMoveSequence(graph).getGroup(0)
+	g.Expect(mover.createGroup(firstGroup, toProxy)).To(Succeed())
+
+	// A new move fails because of the state file left by the interrupted move.
+	g.Expect(mover.setState(MoveOptions{StateFile: stateFile})).ToNot(Succeed())
+
+	// Resumes the interrupted move, re-discovering the objects in the source cluster.
+	resumedGraph := newObjectGraph(graph.proxy)
+	discoveryTypes, err := getFakeDiscoveryTypes(resumedGraph)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resumedGraph.Discovery("ns1", discoveryTypes)).To(Succeed())
+
+	resumedMover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	g.Expect(resumedMover.setState(MoveOptions{StateFile: stateFile, Resume: true})).To(Succeed())
+	g.Expect(resumedMover.state.Created).To(HaveLen(len(firstGroup)))
+	g.Expect(resumedMover.move(resumedGraph, toProxy)).To(Succeed())
+
+	// Objects created by the interrupted move are not created again, and their dependents are linked to them.
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for _, n := range firstGroup {
+		resumedNode := resumedGraph.uidToNode[n.identity.UID]
+		g.Expect(resumedNode.newUID).To(Equal(n.newUID))
+
+		for _, other := range resumedGraph.getNodes() {
+			if !other.isOwnedBy(resumedNode) {
+				continue
+			}
+
+			oTo := &unstructured.Unstructured{}
+			oTo.SetAPIVersion(other.identity.APIVersion)
+			oTo.SetKind(other.identity.Kind)
+			g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: other.identity.Namespace, Name: other.identity.Name}, oTo)).To(Succeed())
+
+			ownerUIDs := []types.UID{}
+			for _, ref := range oTo.GetOwnerReferences() {
+				ownerUIDs = append(ownerUIDs, ref.UID)
+			}
+			g.Expect(ownerUIDs).To(ContainElement(n.newUID))
+		}
+	}
+
+	// The state file is deleted once the move completes.
+	_, err = os.Stat(stateFile)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+}
+
+func Test_objectMover_setState(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, err := ioutil.TempDir("", "cluster-client")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(dir)
+
+	stateFile := filepath.Join(dir, "state.yaml")
+
+	// When only validating, no progress is recorded.
+	mover := objectMover{}
+	g.Expect(mover.setState(MoveOptions{StateFile: stateFile, ValidateOnly: true})).To(Succeed())
+	g.Expect(mover.state).To(BeNil())
+	_, err = os.Stat(stateFile)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	// The state file is not written before the move starts, so a move failing the preflight checks can be run again.
+	g.Expect(mover.setState(MoveOptions{StateFile: stateFile})).To(Succeed())
+	g.Expect(mover.state).ToNot(BeNil())
+	_, err = os.Stat(stateFile)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	g.Expect(mover.setState(MoveOptions{StateFile: stateFile})).To(Succeed())
+
+	// The state file is written once the move starts pausing the source Clusters.
+	g.Expect(mover.state.syncPaused([]*node{{identity: corev1.ObjectReference{UID: "cluster-uid"}}})).To(Succeed())
+	_, err = os.Stat(stateFile)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(mover.setState(MoveOptions{StateFile: stateFile})).ToNot(Succeed())
+}
+
+func Test_objectMover_reset(t *testing.T) {
+	g := NewWithT(t)
+
+	toCluster := newClusterClient(Kubeconfig{}, nil, InjectProxy(test.NewFakeProxy()))
+	mover := newObjectMover(Kubeconfig{}, test.NewFakeProxy(), nil, nil)
+
+	// Each operation fails validating its options, once the mover is set up for it.
+	_, err := mover.Move(toCluster, MoveOptions{Namespace: "ns1", Namespaces: []string{"ns2"}, CopyOnly: true})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(mover.copyOnly).To(BeTrue())
+
+	// Sync does not inherit the copy mode of the previous move.
+	_, err = mover.Sync(toCluster, MoveOptions{Namespace: "ns1", Namespaces: []string{"ns2"}, Diff: true})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(mover.copyOnly).To(BeFalse())
+	g.Expect(mover.syncMode).To(BeTrue())
+	g.Expect(mover.diff).To(BeTrue())
+
+	// Restoring from a directory does not inherit the sync mode of the previous sync.
+	_, err = mover.FromDirectory(toCluster, "not-existing", MoveOptions{RewriteFinalizers: map[string]string{"": "finalizer"}})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(mover.syncMode).To(BeFalse())
+	g.Expect(mover.diff).To(BeFalse())
+	g.Expect(mover.fromDirectory).To(Equal("not-existing"))
+
+	// Move does not inherit anything from the previous operations.
+	_, err = mover.Move(toCluster, MoveOptions{Namespace: "ns1", Namespaces: []string{"ns2"}})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(mover.copyOnly).To(BeFalse())
+	g.Expect(mover.syncMode).To(BeFalse())
+	g.Expect(mover.diff).To(BeFalse())
+	g.Expect(mover.fromDirectory).To(BeEmpty())
+	g.Expect(mover.fromArchive).To(BeNil())
+	g.Expect(mover.fromProxy).ToNot(BeNil())
+}
+
+func Test_objectMover_move_timeout(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph, toProxy := discoverFakeGraph(t, test.NewFakeCluster("ns1", "foo").WithMachines(
+		test.NewFakeMachine("m1"),
+	).Objs())
+
+	// Run move with a timeout expiring immediately.
+	mover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	cancel := mover.setTimeout(time.Nanosecond)
+	defer cancel()
+	<-mover.getContext().Done()
+
+	err := mover.move(graph, toProxy)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("move timed out after 1ns while creating objects in the target cluster"))
+
+	// check that no objects are deleted from the source cluster nor created in the target cluster
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for _, node := range graph.uidToNode {
+		key := client.ObjectKey{
+			Namespace: node.identity.Namespace,
+			Name:      node.identity.Name,
+		}
+
+		oFrom := &unstructured.Unstructured{}
+		oFrom.SetAPIVersion(node.identity.APIVersion)
+		oFrom.SetKind(node.identity.Kind)
+		g.Expect(csFrom.Get(ctx, key, oFrom)).To(Succeed())
+
+		oTo := &unstructured.Unstructured{}
+		oTo.SetAPIVersion(node.identity.APIVersion)
+		oTo.SetKind(node.identity.Kind)
+		g.Expect(apierrors.IsNotFound(csTo.Get(ctx, key, oTo))).To(BeTrue())
+	}
+}
+
+func Test_objectMover_move_toNamespace(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph, _ := discoverFakeGraph(t, test.NewFakeCluster("ns1", "foo").WithMachines(
+		test.NewFakeMachine("m1"),
+	).Objs())
+
+	mover := objectMover{
+		fromProxy:   graph.proxy,
+		toNamespace: "ns1-prod",
+	}
+
+	// Move fails if the target namespace does not exist.
+	g.Expect(mover.ensureNamespaces(graph, getFakeProxyWithCRDs())).ToNot(Succeed())
+
+	// gets a fakeProxy to a cluster with the target namespace
+	toProxy := getFakeProxyWithCRDs().WithObjs(&corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ns1-prod",
+		},
+	})
+
+	g.Expect(mover.move(graph, toProxy)).To(Succeed())
+
+	// check that all the objects are created in the target namespace, with references to other objects remapped
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for _, node := range graph.uidToNode {
+		oTo := &unstructured.Unstructured{}
+		oTo.SetAPIVersion(node.identity.APIVersion)
+		oTo.SetKind(node.identity.Kind)
+		g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1-prod", Name: node.identity.Name}, oTo)).To(Succeed())
+
+		if node.identity.Kind == "Cluster" {
+			namespace, _, err := unstructured.NestedString(oTo.Object, "spec", "infrastructureRef", "namespace")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(namespace).To(Equal("ns1-prod"))
+		}
+	}
+}
+
+func Test_objectMover_preservesAnnotations(t *testing.T) {
+	annotations := map[string]string{
+		"example.com/annotation": "value",
+	}
+
+	tests := []struct {
+		name string
+		move func(mover *objectMover, graph *objectGraph, toProxy Proxy) error
+	}{
+		{
+			name: "move to a target cluster",
+			move: func(mover *objectMover, graph *objectGraph, toProxy Proxy) error {
+				return mover.move(graph, toProxy)
+			},
+		},
+		{
+			name: "save to a directory and restore",
+			move: func(mover *objectMover, graph *objectGraph, toProxy Proxy) error {
+				dir, err := ioutil.TempDir("", "clusterctl")
+				if err != nil {
+					return err
+				}
+				defer os.RemoveAll(dir)
+
+				if err := mover.save(graph, newDirectoryWriter(dir)); err != nil {
+					return err
+				}
+				objs, err := readObjectsFromDirectory(dir)
+				if err != nil {
+					return err
+				}
+				restoredGraph := newObjectGraph(nil)
+				if err := restoredGraph.addRestoredObjs(objs); err != nil {
+					return err
+				}
+				restoredGraph.setSoftOwnership()
+				restoredGraph.setClusterTenants()
+
+				toMover := &objectMover{fromDirectory: dir}
+				return toMover.restore(restoredGraph, toProxy)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test, adding annotations to all the objects.
+			objs := test.NewFakeCluster("ns1", "foo").Objs()
+			for _, obj := range objs {
+				accessor, err := meta.Accessor(obj)
+				g.Expect(err).NotTo(HaveOccurred())
+				accessor.SetAnnotations(annotations)
+			}
+			graph, toProxy := discoverFakeGraph(t, objs)
+
+			mover := &objectMover{
+				fromProxy: graph.proxy,
+			}
+			g.Expect(tt.move(mover, graph, toProxy)).To(Succeed())
+
+			// check that the annotations are preserved on all the objects created in the target cluster
+			csTo, err := toProxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range graph.uidToNode {
+				oTo := &unstructured.Unstructured{}
+				oTo.SetAPIVersion(node.identity.APIVersion)
+				oTo.SetKind(node.identity.Kind)
+				g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: node.identity.Namespace, Name: node.identity.Name}, oTo)).To(Succeed())
+				for key, value := range annotations {
+					g.Expect(oTo.GetAnnotations()).To(HaveKeyWithValue(key, value))
+				}
+			}
+		})
+	}
+}
+
+func Test_objectMover_move_annotateProvenance(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       MoveOptions
+		wantMovedFrom string
+		wantMovedAt   string
+		wantErr       bool
+	}{
+		{
+			name:          "default annotations",
+			options:       MoveOptions{AnnotateProvenance: true},
+			wantMovedFrom: clusterctlv1.ClusterctlMovedFromAnnotation,
+			wantMovedAt:   clusterctlv1.ClusterctlMovedAtAnnotation,
+		},
+		{
+			name:          "custom annotations",
+			options:       MoveOptions{AnnotateProvenance: true, MovedFromAnnotation: "example.com/from", MovedAtAnnotation: "example.com/at"},
+			wantMovedFrom: "example.com/from",
+			wantMovedAt:   "example.com/at",
+		},
+		{
+			name:    "invalid annotation",
+			options: MoveOptions{AnnotateProvenance: true, MovedFromAnnotation: "not a key"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+			graph, toProxy := discoverFakeGraph(t, test.NewFakeCluster("ns1", "foo").Objs())
+
+			mover := objectMover{
+				fromProxy: graph.proxy,
+			}
+			err := mover.setProvenance(tt.options, func() (string, error) {
+				return "mgmt-old", nil
+			})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			g.Expect(mover.move(graph, toProxy)).To(Succeed())
+
+			// check that all the objects created in the target cluster are annotated with the provenance
+			csTo, err := toProxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range graph.uidToNode {
+				oTo := &unstructured.Unstructured{}
+				oTo.SetAPIVersion(node.identity.APIVersion)
+				oTo.SetKind(node.identity.Kind)
+				g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: node.identity.Namespace, Name: node.identity.Name}, oTo)).To(Succeed())
+				g.Expect(oTo.GetAnnotations()).To(HaveKeyWithValue(tt.wantMovedFrom, "mgmt-old"))
+				g.Expect(oTo.GetAnnotations()).To(HaveKey(tt.wantMovedAt))
+
+				_, err := time.Parse(time.RFC3339, oTo.GetAnnotations()[tt.wantMovedAt])
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func Test_remapReferences(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"infrastructureRef": map[string]interface{}{"kind": "DummyInfrastructureCluster", "namespace": "ns1", "name": "foo"},
+			"identityRef":       map[string]interface{}{"kind": "Secret", "namespace": "capa-system", "name": "creds"},
+			"otherRefs": []interface{}{
+				map[string]interface{}{"namespace": "other", "name": "bar"},
+			},
+		},
+	}
+	remapReferences(obj, map[string]string{"ns1": "ns1-prod", "capa-system": "capa-prod"})
+
+	g.Expect(obj).To(Equal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"infrastructureRef": map[string]interface{}{"kind": "DummyInfrastructureCluster", "namespace": "ns1-prod", "name": "foo"},
+			"identityRef":       map[string]interface{}{"kind": "Secret", "namespace": "capa-prod", "name": "creds"},
+			"otherRefs": []interface{}{
+				map[string]interface{}{"namespace": "other", "name": "bar"},
+			},
+		},
+	}))
+}
+
+func Test_rewriteFinalizers(t *testing.T) {
+	tests := []struct {
+		name       string
+		finalizers []string
+		rewrite    map[string]string
+		want       []string
+	}{
+		{
+			name:       "no finalizers to rewrite",
+			finalizers: []string{clusterv1.ClusterFinalizer, "capa-east.example.com/cleanup"},
+			rewrite:    nil,
+			want:       []string{clusterv1.ClusterFinalizer, "capa-east.example.com/cleanup"},
+		},
+		{
+			name:       "rewrites the listed finalizers only",
+			finalizers: []string{clusterv1.ClusterFinalizer, "capa-east.example.com/cleanup"},
+			rewrite:    map[string]string{"capa-east.example.com/cleanup": "capa-west.example.com/cleanup"},
+			want:       []string{clusterv1.ClusterFinalizer, "capa-west.example.com/cleanup"},
+		},
+		{
+			name:       "removes the finalizers mapped to an empty string",
+			finalizers: []string{clusterv1.ClusterFinalizer, "capa-east.example.com/cleanup"},
+			rewrite:    map[string]string{"capa-east.example.com/cleanup": ""},
+			want:       []string{clusterv1.ClusterFinalizer},
+		},
+		{
+			name:       "does not duplicate finalizers already existing",
+			finalizers: []string{"capa-east.example.com/cleanup", "capa-west.example.com/cleanup"},
+			rewrite:    map[string]string{"capa-east.example.com/cleanup": "capa-west.example.com/cleanup"},
+			want:       []string{"capa-west.example.com/cleanup"},
+		},
+		{
+			name:       "removes the Cluster API finalizers only if listed",
+			finalizers: []string{clusterv1.ClusterFinalizer},
+			rewrite:    map[string]string{clusterv1.ClusterFinalizer: ""},
+			want:       []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := &unstructured.Unstructured{}
+			obj.SetFinalizers(tt.finalizers)
+
+			rewriteFinalizers(obj, tt.rewrite)
+			g.Expect(obj.GetFinalizers()).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_getCrossNamespaceReferences(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"infrastructureRef": map[string]interface{}{"apiVersion": "infrastructure.cluster.x-k8s.io/v1alpha3", "kind": "DummyInfrastructureCluster", "namespace": "ns1", "name": "foo"},
+			"identityRef":       map[string]interface{}{"apiVersion": "v1", "kind": "Secret", "namespace": "capa-system", "name": "creds"},
+			"credentials": []interface{}{
+				map[string]interface{}{
+					"secretRef": map[string]interface{}{"namespace": "other", "name": "bar"},
+				},
+			},
+			"unknownRef": map[string]interface{}{"namespace": "other", "name": "baz"},
+		},
+	}
+
+	g.Expect(getCrossNamespaceReferences(obj, "", "ns1")).To(ConsistOf(
+		corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: "capa-system", Name: "creds", FieldPath: "spec.identityRef"},
+		corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: "other", Name: "bar", FieldPath: "spec.credentials[0].secretRef"},
+	))
+}
+
+func Test_objectMover_move_continueOnError(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	objs := []runtime.Object{}
+	objs = append(objs, test.NewFakeCluster("ns1", "foo").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns1", "bar").Objs()...)
+	graph, toProxy := discoverFakeGraph(t, objs)
+
+	// Make moving the infrastructure cluster of bar fail, by deleting it from the source cluster after discovery.
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	failing := &unstructured.Unstructured{}
+	failing.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1alpha3")
+	failing.SetKind("DummyInfrastructureCluster")
+	failing.SetNamespace("ns1")
+	failing.SetName("bar")
+	g.Expect(csFrom.Delete(ctx, failing)).To(Succeed())
+
+	mover := objectMover{
+		fromProxy:       graph.proxy,
+		continueOnError: true,
+	}
+	g.Expect(mover.move(graph, toProxy)).ToNot(Succeed())
+
+	g.Expect(mover.summary.Failed).To(HaveLen(1))
+	g.Expect(mover.summary.Failed[0].Name).To(Equal("bar"))
+	g.Expect(mover.summary.Failed[0].Kind).To(Equal("DummyInfrastructureCluster"))
+
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for _, node := range graph.uidToNode {
+		key := client.ObjectKey{Namespace: node.identity.Namespace, Name: node.identity.Name}
+		oFrom := &unstructured.Unstructured{}
+		oFrom.SetAPIVersion(node.identity.APIVersion)
+		oFrom.SetKind(node.identity.Kind)
+		oTo := &unstructured.Unstructured{}
+		oTo.SetAPIVersion(node.identity.APIVersion)
+		oTo.SetKind(node.identity.Kind)
+
+		_, isFoo := node.tenantClusters[graph.uidToNode[types.UID("cluster.x-k8s.io/v1alpha3, Kind=Cluster, ns1/foo")]]
+		if isFoo {
+			// check that the objects of foo are moved
+			g.Expect(apierrors.IsNotFound(csFrom.Get(ctx, key, oFrom))).To(BeTrue())
+			g.Expect(csTo.Get(ctx, key, oTo)).To(Succeed())
+			continue
+		}
+
+		// check that the objects of bar are kept in the source cluster, with the exception of the failing one
+		if node.identity.Kind != "DummyInfrastructureCluster" {
+			g.Expect(csFrom.Get(ctx, key, oFrom)).To(Succeed())
+		}
+	}
+
+	// check that only foo is resumed in the target cluster
+	for name, paused := range map[string]bool{"foo": false, "bar": true} {
+		cluster := &clusterv1.Cluster{}
+		g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: name}, cluster)).To(Succeed())
+		g.Expect(cluster.Spec.Paused).To(Equal(paused))
+	}
+}
+
+func Test_objectMover_move_skipExisting(t *testing.T) {
+	tests := []struct {
+		name         string
+		skipExisting bool
+		wantData     string
+	}{
+		{
+			name:         "existing objects are overwritten",
+			skipExisting: false,
+			wantData:     "",
+		},
+		{
+			name:         "existing objects are skipped",
+			skipExisting: true,
+			wantData:     "existing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+			graph, _ := discoverFakeGraph(t, test.NewFakeCluster("ns1", "foo").Objs())
+
+			// gets a fakeProxy to a cluster where the kubeconfig secret already exists, with different data.
+			toProxy := getFakeProxyWithCRDs().WithObjs(&corev1.Secret{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "Secret",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "foo-kubeconfig",
+				},
+				StringData: map[string]string{
+					"value": "existing",
+				},
+			})
+
+			mover := objectMover{
+				fromProxy:    graph.proxy,
+				skipExisting: tt.skipExisting,
+			}
+			g.Expect(mover.move(graph, toProxy)).To(Succeed())
+
+			// check that all the objects are created in the target cluster, and that the existing secret is overwritten only if required.
+			csTo, err := toProxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range graph.uidToNode {
+				oTo := &unstructured.Unstructured{}
+				oTo.SetAPIVersion(node.identity.APIVersion)
+				oTo.SetKind(node.identity.Kind)
+				g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: node.identity.Name}, oTo)).To(Succeed())
+			}
+
+			secret := &corev1.Secret{}
+			g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo-kubeconfig"}, secret)).To(Succeed())
+			g.Expect(secret.StringData["value"]).To(Equal(tt.wantData))
+
+			// check that the summary reports the skipped objects separately from the moved ones
+			if tt.skipExisting {
+				g.Expect(mover.summary.Skipped).To(ConsistOf(MoveObject{APIVersion: "v1", Kind: "Secret", Namespace: "ns1", Name: "foo-kubeconfig"}))
+				g.Expect(mover.summary.Moved).To(HaveLen(len(graph.uidToNode) - 1))
+			} else {
+				g.Expect(mover.summary.Skipped).To(BeEmpty())
+				g.Expect(mover.summary.Moved).To(HaveLen(len(graph.uidToNode)))
+			}
+			g.Expect(mover.summary.Failed).To(BeEmpty())
+		})
+	}
+}
+
+func Test_objectMover_syncObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph, toProxy := discoverFakeGraph(t, test.NewFakeCluster("ns1", "foo").Objs())
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		syncMode:  true,
+	}
+	g.Expect(mover.syncObjects(graph, toProxy)).To(Succeed())
+	g.Expect(mover.summary.Moved).To(HaveLen(len(graph.uidToNode)))
+
+	// check that the objects are still in the source cluster and are created in the target cluster.
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for _, node := range graph.uidToNode {
+		key := client.ObjectKey{Namespace: node.identity.Namespace, Name: node.identity.Name}
+
+		oFrom := &unstructured.Unstructured{}
+		oFrom.SetAPIVersion(node.identity.APIVersion)
+		oFrom.SetKind(node.identity.Kind)
+		g.Expect(csFrom.Get(ctx, key, oFrom)).To(Succeed())
+
+		oTo := &unstructured.Unstructured{}
+		oTo.SetAPIVersion(node.identity.APIVersion)
+		oTo.SetKind(node.identity.Kind)
+		g.Expect(csTo.Get(ctx, key, oTo)).To(Succeed())
+	}
+
+	// check that the Cluster is active in the source cluster and paused in the target cluster.
+	clusterFrom := &clusterv1.Cluster{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, clusterFrom)).To(Succeed())
+	g.Expect(clusterFrom.Spec.Paused).To(BeFalse())
+
+	clusterTo := &clusterv1.Cluster{}
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, clusterTo)).To(Succeed())
+	g.Expect(clusterTo.Spec.Paused).To(BeTrue())
+
+	// Syncing again without changes leaves all the objects untouched.
+	mover = objectMover{
+		fromProxy: graph.proxy,
+		syncMode:  true,
+	}
+	g.Expect(mover.syncObjects(graph, toProxy)).To(Succeed())
+	g.Expect(mover.summary.Moved).To(BeEmpty())
+	g.Expect(mover.summary.Skipped).To(HaveLen(len(graph.uidToNode)))
+
+	// Syncing again after changing an object in the source cluster updates only the changed object.
+	secret := &corev1.Secret{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo-kubeconfig"}, secret)).To(Succeed())
+	secret.Labels = map[string]string{"changed": "true"}
+	g.Expect(csFrom.Update(ctx, secret)).To(Succeed())
+
+	mover = objectMover{
+		fromProxy: graph.proxy,
+		syncMode:  true,
+	}
+	g.Expect(mover.syncObjects(graph, toProxy)).To(Succeed())
+	g.Expect(mover.summary.Moved).To(ConsistOf(MoveObject{APIVersion: "v1", Kind: "Secret", Namespace: "ns1", Name: "foo-kubeconfig"}))
+	g.Expect(mover.summary.Skipped).To(HaveLen(len(graph.uidToNode) - 1))
+
+	secret = &corev1.Secret{}
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo-kubeconfig"}, secret)).To(Succeed())
+	g.Expect(secret.Labels).To(HaveKeyWithValue("changed", "true"))
+}
+
+func Test_objectMover_syncObjects_diff(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph, toProxy := discoverFakeGraph(t, test.NewFakeCluster("ns1", "foo").Objs())
+
+	// Diffing with an empty target cluster reports all the objects as created, without creating them.
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		syncMode:  true,
+		diff:      true,
+	}
+	g.Expect(mover.syncObjects(graph, toProxy)).To(Succeed())
+	g.Expect(mover.summary.Diff).To(HaveLen(len(graph.uidToNode)))
+	for _, diff := range mover.summary.Diff {
+		g.Expect(diff.Operation).To(Equal("create"))
+	}
+
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo-kubeconfig"}, &corev1.Secret{})).NotTo(Succeed())
+
+	// Sync, then change an object in the source cluster.
+	mover = objectMover{
+		fromProxy: graph.proxy,
+		syncMode:  true,
+	}
+	g.Expect(mover.syncObjects(graph, toProxy)).To(Succeed())
+
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	secret := &corev1.Secret{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo-kubeconfig"}, secret)).To(Succeed())
+	secret.Labels = map[string]string{"changed": "true"}
+	g.Expect(csFrom.Update(ctx, secret)).To(Succeed())
+
+	// Diffing again reports only the changed object as updated, with the patch, without updating it.
+	mover = objectMover{
+		fromProxy: graph.proxy,
+		syncMode:  true,
+		diff:      true,
+	}
+	g.Expect(mover.syncObjects(graph, toProxy)).To(Succeed())
+	g.Expect(mover.summary.Diff).To(HaveLen(len(graph.uidToNode)))
+	for _, diff := range mover.summary.Diff {
+		if diff.Kind == "Secret" && diff.Name == "foo-kubeconfig" {
+			g.Expect(diff.Operation).To(Equal("update"))
+			g.Expect(diff.Patch).To(Equal(`{"metadata":{"labels":{"changed":"true"}}}`))
+			continue
+		}
+		g.Expect(diff.Operation).To(Equal("unchanged"))
+		g.Expect(diff.Patch).To(BeEmpty())
+	}
+
+	secret = &corev1.Secret{}
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo-kubeconfig"}, secret)).To(Succeed())
+	g.Expect(secret.Labels).NotTo(HaveKey("changed"))
+}
+
+func Test_objectMover_move_events(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph, toProxy := discoverFakeGraph(t, test.NewFakeCluster("ns1", "foo").Objs())
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	g.Expect(mover.move(graph, toProxy)).To(Succeed())
+
+	// check that the events describing the move are recorded on the Cluster in both management clusters.
+	getReasons := func(proxy Proxy) []string {
+		c, err := proxy.NewClient()
+		g.Expect(err).NotTo(HaveOccurred())
+
+		events := &corev1.EventList{}
+		g.Expect(c.List(ctx, events, client.InNamespace("ns1"))).To(Succeed())
+
+		reasons := []string{}
+		for _, event := range events.Items {
+			g.Expect(event.InvolvedObject.Kind).To(Equal("Cluster"))
+			g.Expect(event.InvolvedObject.Name).To(Equal("foo"))
+			g.Expect(event.Source.Component).To(Equal("clusterctl"))
+			reasons = append(reasons, event.Reason)
+		}
+		return reasons
+	}
+	g.Expect(getReasons(graph.proxy)).To(ConsistOf("Moving", "Moved"))
+	g.Expect(getReasons(toProxy)).To(ConsistOf("Moved"))
+
+	// When copying, the events describe a copy, because the Cluster is left in the source management cluster.
+	graph, toProxy = discoverFakeGraph(t, test.NewFakeCluster("ns1", "foo").Objs())
+
+	mover = objectMover{
+		fromProxy: graph.proxy,
+		copyOnly:  true,
+	}
+	g.Expect(mover.move(graph, toProxy)).To(Succeed())
+	g.Expect(getReasons(graph.proxy)).To(ConsistOf("Copying", "Copied"))
+	g.Expect(getReasons(toProxy)).To(ConsistOf("Copied"))
+}
+
+func Test_objectMover_move_preservePaused(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	objs := []runtime.Object{}
+	objs = append(objs, test.NewFakeCluster("ns1", "foo").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns1", "bar").Objs()...)
+	graph := getObjectGraphWithObjs(objs)
+
+	// Pause bar in the source cluster before discovery.
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	bar := &clusterv1.Cluster{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "bar"}, bar)).To(Succeed())
+	bar.Spec.Paused = true
+	g.Expect(csFrom.Update(ctx, bar)).To(Succeed())
+
+	discoveryTypes, err := getFakeDiscoveryTypes(graph)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(graph.Discovery("ns1", discoveryTypes)).To(Succeed())
+
+	// gets a fakeProxy to an empty cluster with all the required CRDs
+	toProxy := getFakeProxyWithCRDs()
+
+	mover := objectMover{
+		fromProxy:      graph.proxy,
+		preservePaused: true,
+	}
+	g.Expect(mover.move(graph, toProxy)).To(Succeed())
+
+	// check that only foo is resumed in the target cluster, while bar is kept paused as it was in the source cluster.
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for name, paused := range map[string]bool{"foo": false, "bar": true} {
+		cluster := &clusterv1.Cluster{}
+		g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: name}, cluster)).To(Succeed())
+		g.Expect(cluster.Spec.Paused).To(Equal(paused))
+	}
+}
+
+func Test_objectMover_verifyObject(t *testing.T) {
+	g := NewWithT(t)
+
+	toProxy := getFakeProxyWithCRDs()
+	cTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Simulates a webhook setting a field that was not set in the object created by move.
+	cluster := &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Cluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "foo",
+		},
+		Spec: clusterv1.ClusterSpec{
+			Paused: true,
+		},
+	}
+	g.Expect(cTo.Create(ctx, cluster)).To(Succeed())
+
+	n := &node{identity: corev1.ObjectReference{APIVersion: clusterv1.GroupVersion.String(), Kind: "Cluster", Namespace: "ns1", Name: "foo"}}
+
+	// An object read back as it was created does not drift.
+	intended := &unstructured.Unstructured{}
+	intended.SetAPIVersion(clusterv1.GroupVersion.String())
+	intended.SetKind("Cluster")
+	g.Expect(cTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, intended)).To(Succeed())
+
+	mover := objectMover{}
+	g.Expect(mover.verifyObject(n, intended, "create", cTo)).To(Succeed())
+	g.Expect(mover.summary.Drift).To(BeEmpty())
+
+	// An object changed by the target cluster drifts, and the drift is reported as a patch.
+	g.Expect(unstructured.SetNestedField(intended.Object, false, "spec", "paused")).To(Succeed())
+	g.Expect(mover.verifyObject(n, intended, "create", cTo)).To(Succeed())
+	g.Expect(mover.summary.Drift).To(HaveLen(1))
+	g.Expect(mover.summary.Drift[0].Name).To(Equal("foo"))
+	g.Expect(mover.summary.Drift[0].Operation).To(Equal("create"))
+	g.Expect(mover.summary.Drift[0].Patch).To(Equal(`{"spec":{"paused":true}}`))
+
+	// Objects moved without any change do not drift.
+	graph, toProxy := discoverFakeGraph(t, test.NewFakeCluster("ns1", "bar").Objs())
+
+	mover = objectMover{
+		fromProxy:     graph.proxy,
+		verifyObjects: true,
+	}
+	g.Expect(mover.move(graph, toProxy)).To(Succeed())
+	g.Expect(mover.summary.Moved).NotTo(BeEmpty())
+	g.Expect(mover.summary.Drift).To(BeEmpty())
+}
+
+func Test_objectMover_move_infrastructureManagedExternally(t *testing.T) {
+	g := NewWithT(t)
+
+	// Mark the infrastructure cluster as managed by an external system.
+	objs := test.NewFakeCluster("ns1", "foo").Objs()
+	var infraCluster runtime.Object
+	for _, obj := range objs {
+		if obj.GetObjectKind().GroupVersionKind().Kind != "DummyInfrastructureCluster" {
+			continue
+		}
+		accessor, err := meta.Accessor(obj)
+		g.Expect(err).NotTo(HaveOccurred())
+		accessor.SetAnnotations(map[string]string{clusterv1.ManagedByAnnotation: ""})
+		infraCluster = obj.DeepCopyObject()
+	}
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph, _ := discoverFakeGraph(t, objs)
+
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Without ExternalInfrastructure, the externally managed objects are moved as usual.
+	g.Expect(excludeManagedExternally(graph, MoveOptions{})).To(BeEmpty())
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	mover.managedExternally = excludeManagedExternally(graph, MoveOptions{ExternalInfrastructure: true})
+	g.Expect(mover.managedExternally).To(HaveLen(1))
+	g.Expect(mover.managedExternally[0].identity.Kind).To(Equal("DummyInfrastructureCluster"))
+	for _, n := range graph.getNodes() {
+		g.Expect(n.identity.Kind).NotTo(Equal("DummyInfrastructureCluster"))
+	}
+
+	// Fails if the externally managed objects do not exist in the target cluster.
+	toProxy := getFakeProxyWithCRDs()
+	g.Expect(mover.checkManagedExternally(toProxy)).ToNot(Succeed())
+
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(csTo.Create(ctx, infraCluster)).To(Succeed())
+	g.Expect(mover.checkManagedExternally(toProxy)).To(Succeed())
+
+	// Moves the Cluster, leaving the externally managed objects in the source cluster.
+	g.Expect(mover.move(graph, toProxy)).To(Succeed())
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, &clusterv1.Cluster{})).To(Succeed())
+	sourceInfraCluster := &unstructured.Unstructured{}
+	sourceInfraCluster.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1alpha3")
+	sourceInfraCluster.SetKind("DummyInfrastructureCluster")
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, sourceInfraCluster)).To(Succeed())
+}
+
+func Test_objectMover_move_renameClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph, toProxy := discoverFakeGraph(t, test.NewFakeCluster("ns1", "foo").WithMachines(test.NewFakeMachine("m1")).Objs())
+
+	// Fails if the Cluster to be renamed is not going to be moved.
+	mover := objectMover{
+		fromProxy:      graph.proxy,
+		renameClusters: map[string]string{"baz": "bar"},
+	}
+	g.Expect(mover.setRenamedObjects(graph)).ToNot(Succeed())
+
+	// Renames the Cluster foo to bar.
+	mover.renameClusters = map[string]string{"foo": "bar"}
+	g.Expect(mover.setRenamedObjects(graph)).To(Succeed())
+
+	g.Expect(mover.move(graph, toProxy)).To(Succeed())
+
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Checks the Cluster and the Secrets named after it are renamed.
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "bar"}, &clusterv1.Cluster{})).To(Succeed())
+	g.Expect(apierrors.IsNotFound(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, &clusterv1.Cluster{}))).To(BeTrue())
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "bar-ca"}, &corev1.Secret{})).To(Succeed())
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "bar-kubeconfig"}, &corev1.Secret{})).To(Succeed())
+
+	// Checks the references to the Cluster are renamed.
+	machine := &clusterv1.Machine{}
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "m1"}, machine)).To(Succeed())
+	g.Expect(machine.Spec.ClusterName).To(Equal("bar"))
+	g.Expect(machine.Labels).To(HaveKeyWithValue(clusterv1.ClusterLabelName, "bar"))
+	g.Expect(machine.OwnerReferences).To(HaveLen(1))
+	g.Expect(machine.OwnerReferences[0].Name).To(Equal("bar"))
+
+	// Checks the infrastructure cluster keeps its name.
+	infraCluster := &unstructured.Unstructured{}
+	infraCluster.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1alpha3")
+	infraCluster.SetKind("DummyInfrastructureCluster")
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, infraCluster)).To(Succeed())
+	g.Expect(infraCluster.GetLabels()).To(HaveKeyWithValue(clusterv1.ClusterLabelName, "bar"))
+}
+
+func Test_objectMover_renameObject(t *testing.T) {
+	mover := objectMover{
+		renamedObjects: map[types.UID]string{"cluster-foo": "bar"},
+		renamedClusters: map[types.NamespacedName]string{
+			{Namespace: "ns1", Name: "foo"}: "bar",
+		},
+		renamedSecrets: map[types.NamespacedName]string{
+			{Namespace: "ns1", Name: "foo-kubeconfig"}: "bar-kubeconfig",
+		},
+	}
+
+	newObj := func(namespace string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"namespace": namespace, "name": "m1"},
+			"spec": map[string]interface{}{
+				"clusterName": "foo",
+				"clusterRef":  map[string]interface{}{"kind": "Cluster", "name": "foo"},
+				"secretRef":   map[string]interface{}{"name": "foo-kubeconfig"},
+				"template":    map[string]interface{}{"name": "foo-kubeconfig"},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		want      map[string]interface{}
+	}{
+		{
+			name:      "Rewrites the references in the namespace of the renamed Cluster",
+			namespace: "ns1",
+			want: map[string]interface{}{
+				"clusterName": "bar",
+				"clusterRef":  map[string]interface{}{"kind": "Cluster", "name": "bar"},
+				"secretRef":   map[string]interface{}{"name": "bar-kubeconfig"},
+				"template":    map[string]interface{}{"name": "foo-kubeconfig"},
+			},
+		},
+		{
+			name:      "Leaves untouched the references in other namespaces",
+			namespace: "ns2",
+			want:      newObj("ns2").Object["spec"].(map[string]interface{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			n := &node{identity: corev1.ObjectReference{Namespace: tt.namespace, Name: "m1", UID: "machine-m1"}}
+			obj := newObj(tt.namespace)
+			mover.renameObject(n, obj)
+
+			g.Expect(obj.GetName()).To(Equal("m1"))
+			g.Expect(obj.Object["spec"]).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_validateRenameClusters(t *testing.T) {
+	tests := []struct {
+		name           string
+		renameClusters map[string]string
+		wantErr        bool
+	}{
+		{
+			name:           "valid name",
+			renameClusters: map[string]string{"foo": "foo-lab"},
+			wantErr:        false,
+		},
+		{
+			name:           "invalid name",
+			renameClusters: map[string]string{"foo": "Foo_Lab"},
+			wantErr:        true,
+		},
+		{
+			name:           "name too long for a label value",
+			renameClusters: map[string]string{"foo": strings.Repeat("a", 64)},
+			wantErr:        true,
+		},
+		{
+			name:           "many Clusters renamed to the same name",
+			renameClusters: map[string]string{"foo": "lab", "bar": "lab"},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateRenameClusters(MoveOptions{RenameClusters: tt.renameClusters})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func Test_objectMover_move_copyOnly(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph, toProxy := discoverFakeGraph(t, test.NewFakeCluster("ns1", "foo").Objs())
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		copyOnly:  true,
+	}
+	g.Expect(mover.move(graph, toProxy)).To(Succeed())
+
+	// check that the source cluster is neither paused nor deleted.
+	phases := []string{}
+	for _, phase := range mover.summary.Phases {
+		phases = append(phases, phase.Name)
+	}
+	g.Expect(phases).To(Equal([]string{
+		"creating target namespaces",
+		"creating objects in the target cluster",
+		"resuming the target cluster",
+	}))
+
+	// check that the objects are still in the source cluster and are created in the target cluster.
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for _, node := range graph.uidToNode {
+		key := client.ObjectKey{Namespace: node.identity.Namespace, Name: node.identity.Name}
+
+		oFrom := &unstructured.Unstructured{}
+		oFrom.SetAPIVersion(node.identity.APIVersion)
+		oFrom.SetKind(node.identity.Kind)
+		g.Expect(csFrom.Get(ctx, key, oFrom)).To(Succeed())
+
+		oTo := &unstructured.Unstructured{}
+		oTo.SetAPIVersion(node.identity.APIVersion)
+		oTo.SetKind(node.identity.Kind)
+		g.Expect(csTo.Get(ctx, key, oTo)).To(Succeed())
+	}
+
+	clusterFrom := &clusterv1.Cluster{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, clusterFrom)).To(Succeed())
+	g.Expect(clusterFrom.Spec.Paused).To(BeFalse())
+}
+
+func Test_objectMover_move_dryRun(t *testing.T) {
+	g := NewWithT(t)
+	// NB. we are testing the move and move sequence using the same set of moveTests, but checking the results at different stages of the move process
+	for _, tt := range moveTests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+			graph, toProxy := discoverFakeGraph(t, tt.fields.objs)
+
+			// Run move with dry-run
+			mover := objectMover{
+				fromProxy: graph.proxy,
+				dryRun:    true,
+			}
+
+			err := mover.move(graph, toProxy)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+
+			// check that the objects are kept in the source cluster and are not created in the target cluster
+			csFrom, err := graph.proxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			csTo, err := toProxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range graph.uidToNode {
+				key := client.ObjectKey{
+					Namespace: node.identity.Namespace,
+					Name:      node.identity.Name,
+				}
+
+				// objects are not deleted from the source cluster
+				oFrom := &unstructured.Unstructured{}
+				oFrom.SetAPIVersion(node.identity.APIVersion)
+				oFrom.SetKind(node.identity.Kind)
+
+				if err := csFrom.Get(ctx, key, oFrom); err != nil {
+					t.Errorf("error = %v when checking for %v kept in source cluster", err, key)
+					continue
+				}
+
+				// objects are not created in the target cluster
+				oTo := &unstructured.Unstructured{}
+				oTo.SetAPIVersion(node.identity.APIVersion)
+				oTo.SetKind(node.identity.Kind)
+
+				err := csTo.Get(ctx, key, oTo)
+				if err == nil {
+					t.Errorf("%v created in target cluster, but it should not be", key)
+					continue
+				}
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("error = %v when checking for %v not created in target cluster", err, key)
+					continue
+				}
+			}
+		})
+	}
+}
+
+func Test_objectMover_toDirectory(t *testing.T) {
+	g := NewWithT(t)
+	// NB. we are testing the move and move sequence using the same set of moveTests, but checking the results at different stages of the move process
+	for _, tt := range moveTests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "clusterctl")
+			g.Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+			graph, _ := discoverFakeGraph(t, tt.fields.objs)
+
+			// Run move to directory
+			mover := objectMover{
+				fromProxy: graph.proxy,
+			}
+
+			err = mover.save(graph, newDirectoryWriter(dir))
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+
+			// check that the saved files are listed in the manifest
+			g.Expect(verifyManifest(dir)).To(Succeed())
+
+			// check that the objects are kept in the source cluster and are saved in the target directory
+			csFrom, err := graph.proxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range graph.uidToNode {
+				key := client.ObjectKey{
+					Namespace: node.identity.Namespace,
+					Name:      node.identity.Name,
+				}
+
+				// objects are not deleted from the source cluster
+				oFrom := &unstructured.Unstructured{}
+				oFrom.SetAPIVersion(node.identity.APIVersion)
+				oFrom.SetKind(node.identity.Kind)
+
+				if err := csFrom.Get(ctx, key, oFrom); err != nil {
+					t.Errorf("error = %v when checking for %v kept in source cluster", err, key)
+					continue
+				}
+
+				// clusters are not left paused in the source cluster
+				if paused, _, _ := unstructured.NestedBool(oFrom.Object, "spec", "paused"); paused {
+					t.Errorf("%v left paused in source cluster", key)
+				}
+
+				// objects are saved in the target directory
+				data, err := ioutil.ReadFile(filepath.Join(dir, node.fileName()))
+				if err != nil {
+					t.Errorf("error = %v when checking for %v saved in target directory", err, key)
+					continue
+				}
+
+				objs, err := utilyaml.ToUnstructured(data)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(objs).To(HaveLen(1))
+				g.Expect(objs[0].GetUID()).To(Equal(node.identity.UID))
+				g.Expect(objs[0].GetNamespace()).To(Equal(node.identity.Namespace))
+				g.Expect(objs[0].GetName()).To(Equal(node.identity.Name))
+			}
+		})
+	}
+}
+
+func Test_objectMover_setPaused(t *testing.T) {
+	g := NewWithT(t)
+	// NB. we are testing the move and move sequence using the same set of moveTests, but checking the results at different stages of the move process
+	for _, tt := range moveTests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, paused := range []bool{true, false} {
+				// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+				graph, _ := discoverFakeGraph(t, tt.fields.objs)
+
+				// Set the paused field only
+				mover := objectMover{
+					fromProxy: graph.proxy,
+				}
+
+				g.Expect(mover.setPaused(graph, paused)).To(Succeed())
+				g.Expect(mover.summary.Total).To(Equal(len(graph.getClusters())))
+
+				// check that the Clusters are kept in the source cluster with the expected paused field
+				csFrom, err := graph.proxy.NewClient()
+				g.Expect(err).NotTo(HaveOccurred())
+
+				for _, node := range graph.getClusters() {
+					key := client.ObjectKey{
+						Namespace: node.identity.Namespace,
+						Name:      node.identity.Name,
+					}
+
+					cluster := &clusterv1.Cluster{}
+					g.Expect(csFrom.Get(ctx, key, cluster)).To(Succeed())
+					g.Expect(cluster.Spec.Paused).To(Equal(paused))
+				}
+			}
+		})
+	}
+}
+
+func Test_objectMover_listObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	objs := append(test.NewFakeCluster("ns1", "foo").Objs(), test.NewFakeCluster("ns1", "bar").Objs()...)
+	objs = append(objs, &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "unrelated"},
+	})
+	graph, _ := discoverFakeGraph(t, objs)
+
+	// Objects not belonging to any Cluster are not listed, because they are not going to be moved.
+	allMover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	allMover.listObjects(graph)
+	g.Expect(allMover.summary.Discovered).ToNot(ContainElement(MoveObject{APIVersion: "v1", Kind: "Secret", Namespace: "ns1", Name: "unrelated"}))
+	g.Expect(allMover.summary.Discovered).To(ContainElement(MoveObject{APIVersion: "cluster.x-k8s.io/v1alpha3", Kind: "Cluster", Namespace: "ns1", Name: "bar"}))
+
+	// List the objects of a single Cluster only.
+	g.Expect(selectObjects(graph, MoveOptions{ClusterName: "foo"})).To(Succeed())
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	mover.listObjects(graph)
+	g.Expect(mover.summary.Discovered).To(HaveLen(mover.summary.Total))
+	g.Expect(mover.summary.Objects).To(HaveKeyWithValue("Cluster", 1))
+	g.Expect(mover.summary.Discovered).To(ContainElement(MoveObject{
+		APIVersion: "cluster.x-k8s.io/v1alpha3",
+		Kind:       "Cluster",
+		Namespace:  "ns1",
+		Name:       "foo",
+	}))
+	for _, obj := range mover.summary.Discovered {
+		g.Expect(obj.Name).NotTo(HavePrefix("bar"))
+	}
+
+	// Objects are listed sorted by kind, namespace and name.
+	nodes := graph.getNodesWithClusterTenants()
+	sortNodes(nodes)
+	for i, n := range nodes {
+		g.Expect(mover.summary.Discovered[i].Kind).To(Equal(n.identity.Kind))
+		g.Expect(mover.summary.Discovered[i].Name).To(Equal(n.identity.Name))
+	}
+
+	// Check that no object is changed in the source cluster.
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+	cluster := &clusterv1.Cluster{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, cluster)).To(Succeed())
+	g.Expect(cluster.Spec.Paused).To(BeFalse())
+}
+
+func Test_objectMover_restore(t *testing.T) {
+	g := NewWithT(t)
+	// NB. we are testing the move and move sequence using the same set of moveTests, but checking the results at different stages of the move process
+	for _, tt := range moveTests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "clusterctl")
+			g.Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+			graph, toProxy := discoverFakeGraph(t, tt.fields.objs)
+
+			// save the content of the source cluster to a directory
+			fromMover := objectMover{
+				fromProxy: graph.proxy,
+			}
+			g.Expect(fromMover.save(graph, newDirectoryWriter(dir))).To(Succeed())
+
+			// rebuild the object graph from the directory
+			objs, err := readObjectsFromDirectory(dir)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			restoredGraph := newObjectGraph(nil)
+			g.Expect(restoredGraph.addRestoredObjs(objs)).To(Succeed())
+			g.Expect(restoredGraph.getNodesWithClusterTenants()).To(HaveLen(len(graph.getNodesWithClusterTenants())))
+
+			// Run restore
+			mover := objectMover{
+				fromDirectory: dir,
+			}
+
+			err = mover.restore(restoredGraph, toProxy)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+
+			// check that the objects are created in the target cluster
+			csTo, err := toProxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range graph.uidToNode {
+				key := client.ObjectKey{
+					Namespace: node.identity.Namespace,
+					Name:      node.identity.Name,
+				}
+
+				oTo := &unstructured.Unstructured{}
+				oTo.SetAPIVersion(node.identity.APIVersion)
+				oTo.SetKind(node.identity.Kind)
+
+				if err := csTo.Get(ctx, key, oTo); err != nil {
+					t.Errorf("error = %v when checking for %v created in target cluster", err, key)
+					continue
+				}
+
+				// clusters are not left paused in the target cluster
+				if paused, _, _ := unstructured.NestedBool(oTo.Object, "spec", "paused"); paused {
+					t.Errorf("%v left paused in target cluster", key)
+				}
+			}
+		})
+	}
+}
+
+func Test_objectMover_ensureNamespaces(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with objects in two namespaces.
+	objs := []runtime.Object{}
+	objs = append(objs, test.NewFakeCluster("ns1", "foo").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns2", "bar").Objs()...)
+	graph := getObjectGraphWithObjs(objs)
+
+	discoveryTypes, err := getFakeDiscoveryTypes(graph)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(graph.Discovery("", discoveryTypes)).To(Succeed())
+
+	// gets a fakeProxy to a cluster where only one of the two namespaces exists
+	toProxy := getFakeProxyWithCRDs().WithObjs(&corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ns1",
+		},
+	})
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	g.Expect(mover.ensureNamespaces(graph, toProxy)).To(Succeed())
+
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for _, namespace := range []string{"ns1", "ns2"} {
+		ns := &corev1.Namespace{}
+		g.Expect(csTo.Get(ctx, client.ObjectKey{Name: namespace}, ns)).To(Succeed())
+	}
+}
+
+func Test_selectClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name         string
+		options      MoveOptions
+		wantClusters []string
+		wantErr      bool
+	}{
+		{
+			name:         "No selection",
+			options:      MoveOptions{},
+			wantClusters: []string{"foo", "bar"},
+			wantErr:      false,
+		},
+		{
+			name:         "Select by name",
+			options:      MoveOptions{ClusterName: "bar"},
+			wantClusters: []string{"bar"},
+			wantErr:      false,
+		},
+		{
+			name:    "Fails if the Cluster with the given name does not exist",
+			options: MoveOptions{ClusterName: "baz"},
+			wantErr: true,
+		},
+		{
+			name:         "Select by label selector",
+			options:      MoveOptions{LabelSelector: "environment=staging"},
+			wantClusters: []string{"foo"},
+			wantErr:      false,
+		},
+		{
+			name:         "Select by name and label selector",
+			options:      MoveOptions{ClusterName: "bar", LabelSelector: "environment"},
+			wantClusters: []string{"bar"},
+			wantErr:      false,
+		},
+		{
+			name:    "Fails if no Cluster matches the label selector",
+			options: MoveOptions{LabelSelector: "environment=dev"},
+			wantErr: true,
+		},
+		{
+			name:    "Fails with an invalid label selector",
+			options: MoveOptions{LabelSelector: "environment in (staging"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := []runtime.Object{}
+			objs = append(objs, test.NewFakeCluster("ns1", "foo").Objs()...)
+			objs = append(objs, test.NewFakeCluster("ns1", "bar").Objs()...)
+
+			graph, err := getDetachedObjectGraphWihObjs(objs)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			graph.setSoftOwnership()
+			graph.setClusterTenants()
+
+			for _, cluster := range graph.getClusters() {
+				switch cluster.identity.Name {
+				case "foo":
+					cluster.labels = map[string]string{"environment": "staging"}
+				case "bar":
+					cluster.labels = map[string]string{"environment": "production"}
+				}
+			}
+
+			err = selectClusters(graph, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			gotClusters := []string{}
+			for _, cluster := range graph.getClusters() {
+				gotClusters = append(gotClusters, cluster.identity.Name)
+			}
+			g.Expect(gotClusters).To(ConsistOf(tt.wantClusters))
+		})
+	}
+}
+
+func Test_excludeKinds(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name      string
+		options   MoveOptions
+		wantKinds []string
+		wantErr   bool
+	}{
+		{
+			name:      "No exclusions",
+			options:   MoveOptions{},
+			wantKinds: []string{"Cluster", "DummyInfrastructureCluster", "Secret", "Secret", "Machine", "DummyInfrastructureMachine", "DummyBootstrapConfig", "Secret", "Secret"},
+			wantErr:   false,
+		},
+		{
+			name:      "Exclude a kind with group",
+			options:   MoveOptions{ExcludeKinds: []string{"infrastructure.cluster.x-k8s.io/DummyInfrastructureMachine"}},
+			wantKinds: []string{"Cluster", "DummyInfrastructureCluster", "Secret", "Secret", "Machine", "DummyBootstrapConfig", "Secret", "Secret"},
+			wantErr:   false,
+		},
+		{
+			name:      "Exclude a kind with dependents",
+			options:   MoveOptions{ExcludeKinds: []string{"cluster.x-k8s.io/machine"}},
+			wantKinds: []string{"Cluster", "DummyInfrastructureCluster", "Secret", "Secret", "DummyInfrastructureMachine", "DummyBootstrapConfig", "Secret", "Secret"},
+			wantErr:   false,
+		},
+		{
+			name:      "Exclude a kind in the core group",
+			options:   MoveOptions{ExcludeKinds: []string{"Secret"}},
+			wantKinds: []string{"Cluster", "DummyInfrastructureCluster", "Machine", "DummyInfrastructureMachine", "DummyBootstrapConfig"},
+			wantErr:   false,
+		},
+		{
+			name:    "Fails if Clusters are excluded",
+			options: MoveOptions{ExcludeKinds: []string{"cluster.x-k8s.io/Cluster"}},
+			wantErr: true,
+		},
+		{
+			name:    "Fails with an invalid kind",
+			options: MoveOptions{ExcludeKinds: []string{"cluster.x-k8s.io/"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := test.NewFakeCluster("ns1", "foo").WithMachines(
+				test.NewFakeMachine("m1"),
+			).Objs()
+
+			graph, err := getDetachedObjectGraphWihObjs(objs)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			graph.setSoftOwnership()
+			graph.setClusterTenants()
+
+			err = excludeKinds(graph, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			gotKinds := []string{}
+			for _, node := range graph.getNodes() {
+				gotKinds = append(gotKinds, node.identity.Kind)
+
+				// Ownership relations with excluded objects should be dropped.
+				for owner := range node.owners {
+					g.Expect(graph.getNodes()).To(ContainElement(owner))
+				}
+				for owner := range node.softOwners {
+					g.Expect(graph.getNodes()).To(ContainElement(owner))
+				}
+			}
+			g.Expect(gotKinds).To(ConsistOf(tt.wantKinds))
+		})
+	}
+}
+
+func Test_selectModifiedSince(t *testing.T) {
+	lastSync := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		since     time.Time
+		modified  []string
+		unknown   []string
+		wantKinds []string
+		wantSkip  int
+	}{
+		{
+			name:      "No since time",
+			since:     time.Time{},
+			wantKinds: []string{"Cluster", "DummyInfrastructureCluster", "Secret", "Secret", "Machine", "DummyInfrastructureMachine", "DummyBootstrapConfig", "Secret", "Secret", "Secret"},
+		},
+		{
+			name:      "No objects modified",
+			since:     lastSync,
+			wantKinds: []string{},
+			wantSkip:  9,
+		},
+		{
+			name:      "Modified objects are selected together with their owners",
+			since:     lastSync,
+			modified:  []string{"DummyInfrastructureMachine"},
+			wantKinds: []string{"Cluster", "Machine", "DummyInfrastructureMachine"},
+			wantSkip:  6,
+		},
+		{
+			name:      "Modified objects are selected together with their soft owners",
+			since:     lastSync,
+			modified:  []string{"Secret"},
+			wantKinds: []string{"Cluster", "Secret", "Secret", "Machine", "DummyBootstrapConfig", "Secret", "Secret", "Secret"},
+			wantSkip:  2,
+		},
+		{
+			name:      "Objects without managedFields are considered modified",
+			since:     lastSync,
+			unknown:   []string{"DummyBootstrapConfig"},
+			wantKinds: []string{"Cluster", "Machine", "DummyBootstrapConfig"},
+			wantSkip:  6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			objs := test.NewFakeCluster("ns1", "foo").WithMachines(
+				test.NewFakeMachine("m1"),
+			).Objs()
+
+			// Objects not belonging to any Cluster are not synced, so they are not counted as skipped.
+			objs = append(objs, &corev1.Secret{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "unrelated", UID: "unrelated"},
+			})
+
+			graph, err := getDetachedObjectGraphWihObjs(objs)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			graph.setSoftOwnership()
+			graph.setClusterTenants()
+
+			for _, node := range graph.getNodes() {
+				node.lastModified = lastSync.Add(-time.Hour)
+				for _, kind := range tt.modified {
+					if node.identity.Kind == kind {
+						node.lastModified = lastSync.Add(time.Hour)
+					}
+				}
+				for _, kind := range tt.unknown {
+					if node.identity.Kind == kind {
+						node.lastModified = time.Time{}
+					}
+				}
+			}
+
+			// The objects skipped because not modified are reported in the summary.
+			mover := objectMover{}
+			mover.recordUnchanged(selectModifiedSince(graph, tt.since))
+			g.Expect(mover.summary.Unchanged).To(Equal(tt.wantSkip))
+
+			gotKinds := []string{}
+			for _, node := range graph.getNodes() {
+				gotKinds = append(gotKinds, node.identity.Kind)
+			}
+			g.Expect(gotKinds).To(ConsistOf(tt.wantKinds))
+		})
+	}
+}
+
+func Test_getLastModified(t *testing.T) {
+	g := NewWithT(t)
+
+	older := metav1.NewTime(time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(time.Date(2020, 6, 2, 10, 0, 0, 0, time.UTC))
+
+	obj := &unstructured.Unstructured{}
+	g.Expect(getLastModified(obj)).To(BeZero())
+
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{Manager: "manager", Time: &newer},
+		{Manager: "clusterctl", Time: &older},
+		{Manager: "other"},
+	})
+	g.Expect(getLastModified(obj)).To(BeTemporally("==", newer.Time))
+}
+
+func Test_selectRootKind(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name      string
+		options   MoveOptions
+		paused    bool
+		wantKinds []string
+		wantErr   bool
+	}{
+		{
+			name:      "No root kind",
+			options:   MoveOptions{},
+			wantKinds: []string{"Cluster", "DummyInfrastructureCluster", "Secret", "Secret", "MachineDeployment", "DummyInfrastructureMachineTemplate", "DummyBootstrapConfigTemplate", "MachineSet", "Machine", "DummyInfrastructureMachine", "DummyBootstrapConfig", "Secret"},
+			wantErr:   false,
+		},
+		{
+			name:      "Move MachineDeployments and their dependents",
+			options:   MoveOptions{RootKind: "cluster.x-k8s.io/MachineDeployment"},
+			paused:    true,
+			wantKinds: []string{"MachineDeployment", "MachineSet", "Machine", "DummyInfrastructureMachine", "DummyBootstrapConfig", "Secret"},
+			wantErr:   false,
+		},
+		{
+			name:    "Fails if the owning Clusters are not paused",
+			options: MoveOptions{RootKind: "cluster.x-k8s.io/MachineDeployment"},
+			paused:  false,
+			wantErr: true,
+		},
+		{
+			name:    "Fails if there are no objects of the root kind",
+			options: MoveOptions{RootKind: "cluster.x-k8s.io/MachinePool"},
+			paused:  true,
+			wantErr: true,
+		},
+		{
+			name:    "Fails if the root kind is Cluster",
+			options: MoveOptions{RootKind: "cluster.x-k8s.io/Cluster"},
+			paused:  true,
+			wantErr: true,
+		},
+		{
+			name:    "Fails together with SharedOnly",
+			options: MoveOptions{RootKind: "cluster.x-k8s.io/MachineDeployment", SharedOnly: true},
+			paused:  true,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := test.NewFakeCluster("ns1", "foo").WithMachineDeployments(
+				test.NewFakeMachineDeployment("md1").WithMachineSets(
+					test.NewFakeMachineSet("ms1").WithMachines(
+						test.NewFakeMachine("m1"),
+					),
+				),
+			).Objs()
+
+			graph, err := getDetachedObjectGraphWihObjs(objs)
 			g.Expect(err).NotTo(HaveOccurred())
 
-			// trigger discovery the content of the source cluster
-			g.Expect(graph.Discovery("ns1", discoveryTypes)).To(Succeed())
+			graph.setSoftOwnership()
+			graph.setClusterTenants()
+			for _, cluster := range graph.getClusters() {
+				cluster.paused = tt.paused
+			}
 
-			moveSequence := getMoveSequence(graph)
-			g.Expect(moveSequence.groups).To(HaveLen(len(tt.wantMoveGroups)))
+			err = selectObjects(graph, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
 
-			for i, gotGroup := range moveSequence.groups {
-				wantGroup := tt.wantMoveGroups[i]
-				gotNodes := []string{}
-				for _, node := range gotGroup {
-					gotNodes = append(gotNodes, string(node.identity.UID))
+			gotKinds := []string{}
+			for _, node := range graph.getNodesWithClusterTenants() {
+				gotKinds = append(gotKinds, node.identity.Kind)
+
+				// Ownership relations with the objects left in the source cluster should be dropped.
+				for owner := range node.owners {
+					g.Expect(graph.getNodes()).To(ContainElement(owner))
 				}
+				for owner := range node.softOwners {
+					g.Expect(graph.getNodes()).To(ContainElement(owner))
+				}
+			}
+			g.Expect(gotKinds).To(ConsistOf(tt.wantKinds))
+		})
+	}
+}
 
-				g.Expect(gotNodes).To(ConsistOf(wantGroup))
+func Test_objectMover_checkTargetCRDs(t *testing.T) {
+	tests := []struct {
+		name               string
+		toProxy            Proxy
+		wantTargetVersions map[schema.GroupKind]string
+		wantErr            bool
+	}{
+		{
+			name:    "All the CRDs exist in the target cluster",
+			toProxy: getFakeProxyWithCRDs(),
+			wantErr: false,
+		},
+		{
+			name:    "Fails if the CRDs do not exist in the target cluster",
+			toProxy: test.NewFakeProxy(),
+			wantErr: true,
+		},
+		{
+			name: "Fails if the CRDs in the target cluster do not define the storage version of the source cluster",
+			toProxy: test.NewFakeProxy().WithObjs(
+				test.FakeCustomResourceDefinition(clusterv1.GroupVersion.Group, "Cluster", "v1alpha2"),
+			),
+			wantErr: true,
+		},
+		{
+			name: "Converts the objects if the target cluster serves a newer version served by the source cluster too",
+			toProxy: test.NewFakeProxy().WithObjs(
+				test.FakeCustomResourceDefinition(clusterv1.GroupVersion.Group, "Cluster", "v1alpha4", "v1beta1"),
+			),
+			wantTargetVersions: map[schema.GroupKind]string{
+				{Group: clusterv1.GroupVersion.Group, Kind: "Cluster"}: "v1alpha4",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			o := &objectMover{
+				fromProxy: test.NewFakeProxy().WithObjs(
+					test.FakeCustomResourceDefinition(clusterv1.GroupVersion.Group, "Cluster", "v1alpha3", "v1alpha4"),
+				),
+			}
+			err := o.checkTargetCRDs(tt.toProxy)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(o.targetVersions).To(HaveLen(len(tt.wantTargetVersions)))
+			for gk, version := range tt.wantTargetVersions {
+				g.Expect(o.targetVersions).To(HaveKeyWithValue(gk, version))
 			}
 		})
 	}
 }
 
-func Test_objectMover_move(t *testing.T) {
+func Test_objectMover_waitTargetReady(t *testing.T) {
+	fakeDeployment := func(availableReplicas int32) *appsv1.Deployment {
+		replicas := int32(1)
+		return &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "infra-system",
+				Name:      "infra-controller-manager",
+				Labels: map[string]string{
+					clusterv1.ProviderLabelName: clusterctlv1.ManifestLabel("infra", clusterctlv1.InfrastructureProviderType),
+				},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+			},
+			Status: appsv1.DeploymentStatus{
+				AvailableReplicas: availableReplicas,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		toProxy Proxy
+		wantErr bool
+	}{
+		{
+			name: "Provider Deployments available",
+			toProxy: test.NewFakeProxy().
+				WithProviderInventory("infra", clusterctlv1.InfrastructureProviderType, "v1.0.0", "infra-system", "").
+				WithObjs(fakeDeployment(1)),
+			wantErr: false,
+		},
+		{
+			name: "Fails if provider Deployments are not available",
+			toProxy: test.NewFakeProxy().
+				WithProviderInventory("infra", clusterctlv1.InfrastructureProviderType, "v1.0.0", "infra-system", "").
+				WithObjs(fakeDeployment(0)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			o := &objectMover{
+				pollImmediateWaiter: func(interval, timeout time.Duration, condition wait.ConditionFunc) error {
+					done, err := condition()
+					if err != nil {
+						return err
+					}
+					if !done {
+						return wait.ErrWaitTimeout
+					}
+					return nil
+				},
+			}
+			toCluster := newClusterClient(Kubeconfig{}, nil, InjectProxy(tt.toProxy))
+
+			err := o.waitTargetReady(toCluster, time.Minute)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("infra-system/infra-controller-manager"))
+
+				// The error can be matched by type, also when wrapped, and still carries the error of the wait.
+				wrapped := errors.Wrap(err, "move failed")
+				g.Expect(errors.Is(wrapped, ErrTargetNotReady)).To(BeTrue())
+				g.Expect(errors.Is(wrapped, wait.ErrWaitTimeout)).To(BeTrue())
+
+				notReady := &TargetNotReadyError{}
+				g.Expect(errors.As(wrapped, &notReady)).To(BeTrue())
+				g.Expect(notReady.Deployments).To(ConsistOf(HavePrefix("infra-system/infra-controller-manager")))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func Test_objectMover_FromDirectory_targetNotReady(t *testing.T) {
 	g := NewWithT(t)
-	// NB. we are testing the move and move sequence using the same set of moveTests, but checking the results at different stages of the move process
-	for _, tt := range moveTests {
+
+	notAvailable := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "infra-system",
+			Name:      "infra-controller-manager",
+			Labels: map[string]string{
+				clusterv1.ProviderLabelName: clusterctlv1.ManifestLabel("infra", clusterctlv1.InfrastructureProviderType),
+			},
+		},
+	}
+	toProxy := test.NewFakeProxy().
+		WithProviderInventory("infra", clusterctlv1.InfrastructureProviderType, "v1.0.0", "infra-system", "").
+		WithObjs(notAvailable)
+	toCluster := newClusterClient(Kubeconfig{}, nil, InjectProxy(toProxy))
+
+	var gotTimeout time.Duration
+	o := &objectMover{
+		pollImmediateWaiter: func(interval, timeout time.Duration, condition wait.ConditionFunc) error {
+			gotTimeout = timeout
+			if done, err := condition(); err != nil || done {
+				return err
+			}
+			return wait.ErrWaitTimeout
+		},
+	}
+
+	// The restore is aborted before reading the directory, waiting for the given target ready timeout.
+	_, err := o.FromDirectory(toCluster, "not-existing", MoveOptions{TargetReadyTimeout: time.Minute})
+	g.Expect(errors.Is(err, ErrTargetNotReady)).To(BeTrue())
+	g.Expect(gotTimeout).To(Equal(time.Minute))
+}
+
+func Test_objectMover_waitForTargetProvisioned(t *testing.T) {
+	tests := []struct {
+		name        string
+		provisioned bool
+		wantErr     bool
+	}{
+		{
+			name:        "Clusters and Machines provisioned",
+			provisioned: true,
+			wantErr:     false,
+		},
+		{
+			name:        "Fails if Clusters and Machines are not provisioned",
+			provisioned: false,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
 			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
-			graph := getObjectGraphWithObjs(tt.fields.objs)
+			graph, _ := discoverFakeGraph(t, test.NewFakeCluster("ns1", "foo").WithMachines(test.NewFakeMachine("m1")).Objs())
 
-			// Get all the types to be considered for discovery
-			discoveryTypes, err := getFakeDiscoveryTypes(graph)
-			g.Expect(err).NotTo(HaveOccurred())
+			// Nb. The objects in the source cluster are used as the moved objects in the target cluster.
+			toProxy := graph.proxy
+			if tt.provisioned {
+				cTo, err := toProxy.NewClient()
+				g.Expect(err).NotTo(HaveOccurred())
 
-			// trigger discovery the content of the source cluster
-			g.Expect(graph.Discovery("ns1", discoveryTypes)).To(Succeed())
+				cluster := &clusterv1.Cluster{}
+				g.Expect(cTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, cluster)).To(Succeed())
+				cluster.Status.InfrastructureReady = true
+				cluster.Status.ControlPlaneInitialized = true
+				g.Expect(cTo.Update(ctx, cluster)).To(Succeed())
 
-			// gets a fakeProxy to an empty cluster with all the required CRDs
-			toProxy := getFakeProxyWithCRDs()
+				machine := &clusterv1.Machine{}
+				g.Expect(cTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "m1"}, machine)).To(Succeed())
+				machine.Status.NodeRef = &corev1.ObjectReference{Kind: "Node", Name: "m1"}
+				g.Expect(cTo.Update(ctx, machine)).To(Succeed())
+			}
 
-			// Run move
-			mover := objectMover{
-				fromProxy: graph.proxy,
+			o := &objectMover{
+				waitForCompletion: true,
+				pollImmediateWaiter: func(interval, timeout time.Duration, condition wait.ConditionFunc) error {
+					done, err := condition()
+					if err != nil {
+						return err
+					}
+					if !done {
+						return wait.ErrWaitTimeout
+					}
+					return nil
+				},
 			}
 
-			err = mover.move(graph, toProxy)
+			err := o.waitForTargetProvisioned(graph, toProxy)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("Cluster ns1/foo"))
+				g.Expect(err.Error()).To(ContainSubstring("Machine ns1/m1"))
 				return
 			}
-
 			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
 
-			// check that the objects are removed from the source cluster and are created in the target cluster
-			csFrom, err := graph.proxy.NewClient()
-			g.Expect(err).NotTo(HaveOccurred())
+func Test_objectMover_waitSourceDeleted(t *testing.T) {
+	tests := []struct {
+		name        string
+		deleteObjs  bool
+		wantErr     bool
+		wantErrText []string
+	}{
+		{
+			name:       "all the objects deleted",
+			deleteObjs: true,
+			wantErr:    false,
+		},
+		{
+			name:       "objects not deleted",
+			deleteObjs: false,
+			wantErr:    true,
+			wantErrText: []string{
+				"Cluster ns1/foo (finalizers: cluster.cluster.x-k8s.io)",
+				"Secret ns1/foo-kubeconfig",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
 
-			csTo, err := toProxy.NewClient()
+			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+			graph, _ := discoverFakeGraph(t, test.NewFakeCluster("ns1", "foo").Objs())
+
+			// Add a finalizer to the Cluster, so it is reported when the Cluster is not deleted.
+			csFrom, err := graph.proxy.NewClient()
 			g.Expect(err).NotTo(HaveOccurred())
 
-			for _, node := range graph.uidToNode {
-				key := client.ObjectKey{
-					Namespace: node.identity.Namespace,
-					Name:      node.identity.Name,
-				}
+			cluster := &clusterv1.Cluster{}
+			g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, cluster)).To(Succeed())
+			cluster.Finalizers = []string{clusterv1.ClusterFinalizer}
+			g.Expect(csFrom.Update(ctx, cluster)).To(Succeed())
 
-				// objects are deleted from the source cluster
-				oFrom := &unstructured.Unstructured{}
-				oFrom.SetAPIVersion(node.identity.APIVersion)
-				oFrom.SetKind(node.identity.Kind)
+			mover := objectMover{
+				fromProxy:     graph.proxy,
+				deleteTimeout: 10 * time.Millisecond,
+			}
 
-				err := csFrom.Get(ctx, key, oFrom)
-				if err == nil {
-					t.Errorf("%v not deleted in source cluster", key)
-					continue
+			moveSequence := getMoveSequence(graph)
+			if tt.deleteObjs {
+				for i := range moveSequence.groups {
+					g.Expect(mover.deleteGroup(moveSequence.getGroup(i))).To(Succeed())
 				}
-				if !apierrors.IsNotFound(err) {
-					t.Errorf("error = %v when checking for %v deleted in source cluster", err, key)
-					continue
+			}
+
+			err = mover.waitSourceDeleted(moveSequence)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				for _, text := range tt.wantErrText {
+					g.Expect(err.Error()).To(ContainSubstring(text))
 				}
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
 
-				// objects are created in the target cluster
-				oTo := &unstructured.Unstructured{}
-				oTo.SetAPIVersion(node.identity.APIVersion)
-				oTo.SetKind(node.identity.Kind)
+func Test_isTransientError(t *testing.T) {
+	gr := schema.GroupResource{Group: "cluster.x-k8s.io", Resource: "clusters"}
 
-				if err := csTo.Get(ctx, key, oTo); err != nil {
-					t.Errorf("error = %v when checking for %v created in target cluster", err, key)
-					continue
-				}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "ServerTimeout is transient",
+			err:  apierrors.NewServerTimeout(gr, "create", 1),
+			want: true,
+		},
+		{
+			name: "Timeout is transient",
+			err:  apierrors.NewTimeoutError("timeout", 1),
+			want: true,
+		},
+		{
+			name: "TooManyRequests is transient, also when wrapped",
+			err:  errors.Wrap(apierrors.NewTooManyRequests("throttled", 1), "error creating"),
+			want: true,
+		},
+		{
+			name: "Connection refused is transient",
+			err:  &url.Error{Op: "Post", URL: "https://127.0.0.1:6443", Err: &net.OpError{Op: "dial", Err: &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED}}},
+			want: true,
+		},
+		{
+			name: "Invalid fails fast",
+			err:  apierrors.NewInvalid(schema.GroupKind{Group: "cluster.x-k8s.io", Kind: "Cluster"}, "foo", nil),
+			want: false,
+		},
+		{
+			name: "Forbidden fails fast",
+			err:  apierrors.NewForbidden(gr, "foo", errors.New("forbidden")),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(isTransientError(tt.err)).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_retryWithExponentialBackoffContextIf(t *testing.T) {
+	gr := schema.GroupResource{Group: "cluster.x-k8s.io", Resource: "clusters"}
+	backoff := wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+	tests := []struct {
+		name         string
+		errs         []error
+		wantErr      bool
+		wantAttempts int
+	}{
+		{
+			name:         "Retries transient errors until the operation passes",
+			errs:         []error{apierrors.NewServerTimeout(gr, "create", 1), apierrors.NewTooManyRequests("throttled", 1), nil},
+			wantErr:      false,
+			wantAttempts: 3,
+		},
+		{
+			name:         "Fails after the configured number of attempts",
+			errs:         []error{apierrors.NewServerTimeout(gr, "create", 1), apierrors.NewServerTimeout(gr, "create", 1), apierrors.NewServerTimeout(gr, "create", 1)},
+			wantErr:      true,
+			wantAttempts: 3,
+		},
+		{
+			name:         "Fails fast on errors that are not transient",
+			errs:         []error{apierrors.NewForbidden(gr, "foo", errors.New("forbidden")), nil},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			attempts := 0
+			err := retryWithExponentialBackoffContextIf(ctx, backoff, isTransientError, func() error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
 			}
+			g.Expect(attempts).To(Equal(tt.wantAttempts))
+		})
+	}
+}
+
+func Test_objectMover_newRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name      string
+		retries   int
+		wantSteps int
+	}{
+		{
+			name:      "Does not retry if retries are not set",
+			retries:   0,
+			wantSteps: 1,
+		},
+		{
+			name:      "Retries the given number of times",
+			retries:   9,
+			wantSteps: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			o := &objectMover{retries: tt.retries}
+			g.Expect(o.newRetryBackoff().Steps).To(Equal(tt.wantSteps))
 		})
 	}
 }
@@ -636,19 +2909,12 @@ func Test_objectMover_checkProvisioningCompleted(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
-			graph := getObjectGraphWithObjs(tt.fields.objs)
-
-			// Get all the types to be considered for discovery
-			discoveryTypes, err := getFakeDiscoveryTypes(graph)
-			g.Expect(err).NotTo(HaveOccurred())
-
-			// trigger discovery the content of the source cluster
-			g.Expect(graph.Discovery("ns1", discoveryTypes)).To(Succeed())
+			graph, _ := discoverFakeGraph(t, tt.fields.objs)
 
 			o := &objectMover{
 				fromProxy: graph.proxy,
 			}
-			err = o.checkProvisioningCompleted(graph)
+			err := o.checkProvisioningCompleted(graph)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 			} else {
@@ -781,6 +3047,11 @@ func Test_objectsMoverService_checkTargetProviders(t *testing.T) {
 			err := o.checkTargetProviders(tt.args.namespace, newInventoryClient(tt.args.toProxy, nil))
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
+				g.Expect(errors.Is(err, ErrMissingProviders)).To(BeTrue())
+
+				missing := &MissingProvidersError{}
+				g.Expect(errors.As(err, &missing)).To(BeTrue())
+				g.Expect(missing.Providers).To(Equal([]string{"capi"}))
 			} else {
 				g.Expect(err).NotTo(HaveOccurred())
 			}
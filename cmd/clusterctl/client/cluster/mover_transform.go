@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// ObjectTransformer changes an object read from the source management cluster before it is created in the target management
+// cluster, e.g. for rewriting fields specific to an environment; the apiVersion, kind, namespace and name of the object
+// can't be changed.
+type ObjectTransformer func(obj *unstructured.Unstructured) error
+
+// transformRules defines the content of a file with the rules for transforming the objects before they are created in the
+// target management cluster, e.g.
+//
+//	rules:
+//	- kind: infrastructure.cluster.x-k8s.io/AWSCluster
+//	  set:
+//	  - path: [spec, region]
+//	    value: eu-west-1
+//	  - path: [metadata, labels, topology.kubernetes.io/region]
+//	    value: eu-west-1
+type transformRules struct {
+	Rules []transformRule `json:"rules"`
+}
+
+// transformRule sets fields on the objects matching a kind, in the group/kind format, and optionally a name.
+type transformRule struct {
+	// Kind of the objects the rule applies to, in the group/kind format; kinds in the core group can be specified without group.
+	Kind string `json:"kind"`
+
+	// Name of the object the rule applies to. If empty, the rule applies to all the objects of the kind.
+	Name string `json:"name,omitempty"`
+
+	// Set lists the fields to be set on the matching objects.
+	Set []transformField `json:"set"`
+}
+
+// transformField defines the value of a field, identified by the list of the keys leading to it, e.g. [spec, region].
+type transformField struct {
+	Path  []string    `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// NewObjectTransformerFromFile returns an ObjectTransformer applying the rules defined in a file.
+func NewObjectTransformerFromFile(path string) (ObjectTransformer, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the transform rules file %q", path)
+	}
+
+	rules := &transformRules{}
+	if err := yaml.UnmarshalStrict(data, rules); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the transform rules file %q", path)
+	}
+
+	kinds := make([]schema.GroupKind, 0, len(rules.Rules))
+	for i, rule := range rules.Rules {
+		gk, err := parseGroupKind(rule.Kind)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rule %d in the transform rules file %q", i+1, path)
+		}
+		for _, field := range rule.Set {
+			if len(field.Path) == 0 {
+				return nil, errors.Errorf("invalid rule %d in the transform rules file %q: the path of the fields to be set can't be empty", i+1, path)
+			}
+		}
+		kinds = append(kinds, gk)
+	}
+
+	return func(obj *unstructured.Unstructured) error {
+		for i, rule := range rules.Rules {
+			if obj.GroupVersionKind().GroupKind() != kinds[i] || (rule.Name != "" && rule.Name != obj.GetName()) {
+				continue
+			}
+			for _, field := range rule.Set {
+				if err := unstructured.SetNestedField(obj.Object, field.Value, field.Path...); err != nil {
+					return errors.Wrapf(err, "failed to set field %v", field.Path)
+				}
+			}
+		}
+		return nil
+	}, nil
+}
+
+// transformObject applies the transformers to an object, checking that the object identity is not changed.
+func transformObject(obj *unstructured.Unstructured, transformers []ObjectTransformer) error {
+	if len(transformers) == 0 {
+		return nil
+	}
+
+	gvk, namespace, name := obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName()
+	for _, transform := range transformers {
+		if err := transform(obj); err != nil {
+			return errors.Wrapf(err, "failed to transform %q %s/%s", gvk, namespace, name)
+		}
+	}
+
+	if obj.GroupVersionKind() != gvk || obj.GetNamespace() != namespace || obj.GetName() != name {
+		return errors.Errorf("failed to transform %q %s/%s: the apiVersion, kind, namespace and name of the object can't be changed", gvk, namespace, name)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const transformRulesFile = `rules:
+- kind: Secret
+  name: foo-kubeconfig
+  set:
+  - path: [metadata, labels, topology.kubernetes.io/region]
+    value: eu-west-1
+- kind: cluster.x-k8s.io/Cluster
+  set:
+  - path: [metadata, annotations, environment]
+    value: prod
+`
+
+func Test_NewObjectTransformerFromFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr bool
+	}{
+		{
+			name:    "Valid rules",
+			rules:   transformRulesFile,
+			wantErr: false,
+		},
+		{
+			name:    "Fails for unknown fields",
+			rules:   "rules:\n- kind: Secret\n  sett: []\n",
+			wantErr: true,
+		},
+		{
+			name:    "Fails for invalid kinds",
+			rules:   "rules:\n- kind: infrastructure.cluster.x-k8s.io/\n",
+			wantErr: true,
+		},
+		{
+			name:    "Fails for empty paths",
+			rules:   "rules:\n- kind: Secret\n  set:\n  - value: foo\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			dir, err := ioutil.TempDir("", "clusterctl")
+			g.Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "transform.yaml")
+			g.Expect(ioutil.WriteFile(path, []byte(tt.rules), 0600)).To(Succeed())
+
+			_, err = NewObjectTransformerFromFile(path)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func Test_transformObject(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("Secret")
+	obj.SetNamespace("ns1")
+	obj.SetName("foo")
+
+	// Transformers are applied in order.
+	g.Expect(transformObject(obj, []ObjectTransformer{
+		func(obj *unstructured.Unstructured) error {
+			obj.SetLabels(map[string]string{"region": "eu-west-1"})
+			return nil
+		},
+		func(obj *unstructured.Unstructured) error {
+			obj.SetAnnotations(map[string]string{"region": obj.GetLabels()["region"]})
+			return nil
+		},
+	})).To(Succeed())
+	g.Expect(obj.GetAnnotations()).To(HaveKeyWithValue("region", "eu-west-1"))
+
+	// Transformers can't change the identity of the object.
+	g.Expect(transformObject(obj, []ObjectTransformer{
+		func(obj *unstructured.Unstructured) error {
+			obj.SetName("bar")
+			return nil
+		},
+	})).NotTo(Succeed())
+}
+
+func Test_objectMover_move_transform(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, err := ioutil.TempDir("", "clusterctl")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "transform.yaml")
+	g.Expect(ioutil.WriteFile(path, []byte(transformRulesFile), 0600)).To(Succeed())
+
+	transformer, err := NewObjectTransformerFromFile(path)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph := getObjectGraphWithObjs(test.NewFakeCluster("ns1", "foo").Objs())
+
+	discoveryTypes, err := getFakeDiscoveryTypes(graph)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(graph.Discovery("ns1", discoveryTypes)).To(Succeed())
+
+	// gets a fakeProxy to an empty cluster with all the required CRDs
+	toProxy := getFakeProxyWithCRDs()
+
+	mover := objectMover{
+		fromProxy:    graph.proxy,
+		transformers: []ObjectTransformer{transformer},
+	}
+	g.Expect(mover.move(graph, toProxy)).To(Succeed())
+
+	// check that only the objects matching the rules are transformed in the target cluster.
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	secret := &corev1.Secret{}
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo-kubeconfig"}, secret)).To(Succeed())
+	g.Expect(secret.Labels).To(HaveKeyWithValue("topology.kubernetes.io/region", "eu-west-1"))
+
+	secret = &corev1.Secret{}
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo-ca"}, secret)).To(Succeed())
+	g.Expect(secret.Labels).NotTo(HaveKey("topology.kubernetes.io/region"))
+
+	cluster := &unstructured.Unstructured{}
+	cluster.SetAPIVersion("cluster.x-k8s.io/v1alpha3")
+	cluster.SetKind("Cluster")
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, cluster)).To(Succeed())
+	g.Expect(cluster.GetAnnotations()).To(HaveKeyWithValue("environment", "prod"))
+}
@@ -17,15 +17,20 @@ limitations under the License.
 package cluster
 
 import (
+	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
@@ -43,6 +48,9 @@ type ownerReferenceAttributes struct {
 type node struct {
 	identity corev1.ObjectReference
 
+	// labels stores the labels of the object, as observed during discovery.
+	labels map[string]string
+
 	// owners contains the list of nodes that are owned by the current node.
 	owners map[*node]ownerReferenceAttributes
 
@@ -50,6 +58,18 @@ type node struct {
 	// E.g. secrets are soft-owned by a cluster via a naming convention, but without an explicit OwnerReference.
 	softOwners map[*node]empty
 
+	// paused stores the value of the spec.paused field of the object, as observed during discovery, i.e. before the move
+	// pauses the Clusters in the source management cluster.
+	paused bool
+
+	// managedExternally records if the object has the cluster.x-k8s.io/managed-by annotation, i.e. the infrastructure
+	// it represents is managed by an external system.
+	managedExternally bool
+
+	// lastModified stores the latest time recorded in the managedFields of the object, as observed during discovery;
+	// it is zero if the object has no managedFields.
+	lastModified time.Time
+
 	// virtual records if this node was discovered indirectly, e.g. by processing an OwnerRef, but not yet observed as a concrete object.
 	virtual bool
 
@@ -84,10 +104,28 @@ func (n *node) isSoftOwnedBy(other *node) bool {
 	return ok
 }
 
+// fileName returns the name of the file used for saving the object corresponding to the node in a directory,
+// e.g. machine.cluster.x-k8s.io_ns1_m1.yaml or secret_ns1_foo-kubeconfig.yaml.
+func (n *node) fileName() string {
+	gvk := n.identity.GroupVersionKind()
+	kind := strings.ToLower(gvk.Kind)
+	if gvk.Group != "" {
+		kind = kind + "." + gvk.Group
+	}
+	return fmt.Sprintf("%s_%s_%s.yaml", kind, n.identity.Namespace, n.identity.Name)
+}
+
 // objectGraph manages the Kubernetes object graph that is generated during the discovery phase for the move operation.
 type objectGraph struct {
 	proxy     Proxy
 	uidToNode map[types.UID]*node
+
+	// strictDiscovery makes the discovery fail when a type is not served by the cluster, instead of skipping it.
+	strictDiscovery bool
+
+	// skippedKinds lists the kinds skipped during the discovery because not served by the cluster, e.g. because the
+	// provider defining them was partially uninstalled.
+	skippedKinds []string
 }
 
 func newObjectGraph(proxy Proxy) *objectGraph {
@@ -145,6 +183,10 @@ func (o *objectGraph) objToNode(obj *unstructured.Unstructured) *node {
 	existingNode, found := o.uidToNode[obj.GetUID()]
 	if found {
 		existingNode.markObserved()
+		existingNode.labels = obj.GetLabels()
+		existingNode.paused = isPaused(obj)
+		existingNode.managedExternally = isManagedExternally(obj)
+		existingNode.lastModified = getLastModified(obj)
 		return existingNode
 	}
 
@@ -156,16 +198,44 @@ func (o *objectGraph) objToNode(obj *unstructured.Unstructured) *node {
 			Name:       obj.GetName(),
 			Namespace:  obj.GetNamespace(),
 		},
-		owners:         make(map[*node]ownerReferenceAttributes),
-		softOwners:     make(map[*node]empty),
-		tenantClusters: make(map[*node]empty),
-		virtual:        false,
+		labels:            obj.GetLabels(),
+		paused:            isPaused(obj),
+		managedExternally: isManagedExternally(obj),
+		lastModified:      getLastModified(obj),
+		owners:            make(map[*node]ownerReferenceAttributes),
+		softOwners:        make(map[*node]empty),
+		tenantClusters:    make(map[*node]empty),
+		virtual:           false,
 	}
 
 	o.uidToNode[newNode.identity.UID] = newNode
 	return newNode
 }
 
+// isPaused returns true if the spec.paused field of the object is set, e.g. on a Cluster whose reconciliation was paused.
+func isPaused(obj *unstructured.Unstructured) bool {
+	paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused")
+	return paused
+}
+
+// isManagedExternally returns true if the object has the cluster.x-k8s.io/managed-by annotation.
+func isManagedExternally(obj *unstructured.Unstructured) bool {
+	_, ok := obj.GetAnnotations()[clusterv1.ManagedByAnnotation]
+	return ok
+}
+
+// getLastModified returns the latest time recorded in the managedFields of the object, or zero if the object has no managedFields.
+// Nb. The resourceVersion can't be used for this purpose, because it is not comparable across clusters.
+func getLastModified(obj *unstructured.Unstructured) time.Time {
+	var lastModified time.Time
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Time != nil && entry.Time.After(lastModified) {
+			lastModified = entry.Time.Time
+		}
+	}
+	return lastModified
+}
+
 // getDiscoveryTypes returns the list of TypeMeta to be considered for the the move discovery phase.
 // This list includes all the types defines by the CRDs installed by clusterctl and the ConfigMap/Secret core types.
 func (o *objectGraph) getDiscoveryTypes() ([]metav1.TypeMeta, error) {
@@ -206,6 +276,13 @@ func (o *objectGraph) getDiscoveryTypes() ([]metav1.TypeMeta, error) {
 // Discovery reads all the Kubernetes objects existing in a namespace (or in all namespaces if empty) for the types received in input, and then adds
 // everything to the objects graph.
 func (o *objectGraph) Discovery(namespace string, types []metav1.TypeMeta) error {
+	return o.DiscoveryInNamespaces([]string{namespace}, types)
+}
+
+// DiscoveryInNamespaces reads all the Kubernetes objects existing in a list of namespaces (or in all namespaces if the list
+// is empty or includes the empty namespace) for the types received in input, and then adds everything to the objects graph;
+// objects from all the namespaces are added to the same graph, so owner references across namespaces are preserved.
+func (o *objectGraph) DiscoveryInNamespaces(namespaces []string, types []metav1.TypeMeta) error {
 	log := logf.Log
 	log.Info("Discovering Cluster API objects")
 
@@ -214,27 +291,44 @@ func (o *objectGraph) Discovery(namespace string, types []metav1.TypeMeta) error
 		return err
 	}
 
-	selectors := []client.ListOption{}
-	if namespace != "" {
-		selectors = append(selectors, client.InNamespace(namespace))
+	selectorsList := [][]client.ListOption{}
+	for _, namespace := range namespaces {
+		if namespace == "" {
+			selectorsList = nil
+			break
+		}
+		selectorsList = append(selectorsList, []client.ListOption{client.InNamespace(namespace)})
+	}
+	if len(selectorsList) == 0 {
+		selectorsList = append(selectorsList, []client.ListOption{})
 	}
 
 	for _, typeMeta := range types {
-		objList := new(unstructured.UnstructuredList)
-		objList.SetAPIVersion(typeMeta.APIVersion)
-		objList.SetKind(typeMeta.Kind)
-
-		if err := c.List(ctx, objList, selectors...); err != nil {
-			if apierrors.IsNotFound(err) {
-				continue
+		for _, selectors := range selectorsList {
+			objList := new(unstructured.UnstructuredList)
+			objList.SetAPIVersion(typeMeta.APIVersion)
+			objList.SetKind(typeMeta.Kind)
+
+			if err := c.List(ctx, objList, selectors...); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				// Skips the types not served by the cluster, e.g. left behind by a provider partially uninstalled, so
+				// the other objects can still be moved.
+				if meta.IsNoMatchError(err) && !o.strictDiscovery {
+					kind := objList.GroupVersionKind().GroupKind().String()
+					log.Info("Warning: skipping a kind not served by the cluster, the objects of this kind are not going to be moved", "Kind", kind, "Error", err.Error())
+					o.skippedKinds = append(o.skippedKinds, kind)
+					break
+				}
+				return errors.Wrapf(err, "failed to list %q resources", objList.GroupVersionKind())
 			}
-			return errors.Wrapf(err, "failed to list %q resources", objList.GroupVersionKind())
-		}
 
-		log.V(5).Info(typeMeta.Kind, "Count", len(objList.Items))
-		for i := range objList.Items {
-			obj := objList.Items[i]
-			o.addObj(&obj)
+			log.V(5).Info(typeMeta.Kind, "Count", len(objList.Items))
+			for i := range objList.Items {
+				obj := objList.Items[i]
+				o.addObj(&obj)
+			}
 		}
 	}
 
@@ -244,6 +338,34 @@ func (o *objectGraph) Discovery(namespace string, types []metav1.TypeMeta) error
 	// by a naming convention (without any explicit OwnerReference).
 	o.setSoftOwnership()
 
+	// Checks the ownership relations before walking them, because a cycle would prevent the objects from being moved.
+	if err := o.checkOwnership(); err != nil {
+		return err
+	}
+
+	// Completes the graph by setting for each node the list of Clusters the node belong to.
+	o.setClusterTenants()
+
+	return nil
+}
+
+// addRestoredObjs adds a list of Kubernetes objects, e.g. read from a directory, to the object graph, and then completes the graph
+// the same way Discovery does.
+func (o *objectGraph) addRestoredObjs(objs []unstructured.Unstructured) error {
+	for i := range objs {
+		obj := objs[i]
+		o.addObj(&obj)
+	}
+
+	// Completes the graph by searching for soft ownership relations such as secrets linked to the cluster
+	// by a naming convention (without any explicit OwnerReference).
+	o.setSoftOwnership()
+
+	// Checks the ownership relations before walking them, because a cycle would prevent the objects from being moved.
+	if err := o.checkOwnership(); err != nil {
+		return err
+	}
+
 	// Completes the graph by setting for each node the list of Clusters the node belong to.
 	o.setClusterTenants()
 
@@ -328,6 +450,98 @@ func (o *objectGraph) setSoftOwnership() {
 	}
 }
 
+// checkOwnership logs the shape of the ownership relations in the graph, i.e. the total number of owner references and soft
+// ownership relations and the maximum depth of the ownership chains, and returns an error naming the objects involved if
+// the ownership relations contain a cycle, which indicates malformed owner references.
+func (o *objectGraph) checkOwnership() error {
+	const (
+		notVisited = iota
+		visiting
+		visited
+	)
+
+	nodes := o.getNodes()
+	sortNodes(nodes)
+
+	state := make(map[*node]int, len(nodes))
+	depth := make(map[*node]int, len(nodes))
+	edges, maxDepth := 0, 0
+
+	// Walks the owners of each node depth first, keeping track of the path from the node being checked, so the path can
+	// be reported if it loops back to an object already in it.
+	var path []*node
+	var visit func(n *node) error
+	visit = func(n *node) error {
+		switch state[n] {
+		case visited:
+			return nil
+		case visiting:
+			start := len(path) - 1
+			for path[start] != n {
+				start--
+			}
+			cycle := []corev1.ObjectReference{}
+			for _, other := range path[start:] {
+				cycle = append(cycle, other.identity)
+			}
+			cycle = append(cycle, n.identity)
+			return &DependencyCycleError{Objects: cycle}
+		}
+
+		state[n] = visiting
+		path = append(path, n)
+
+		owners := []*node{}
+		for owner := range n.owners {
+			owners = append(owners, owner)
+		}
+		for owner := range n.softOwners {
+			owners = append(owners, owner)
+		}
+		sortNodes(owners)
+
+		depth[n] = 0
+		for _, owner := range owners {
+			edges++
+			if err := visit(owner); err != nil {
+				return err
+			}
+			if depth[owner]+1 > depth[n] {
+				depth[n] = depth[owner] + 1
+			}
+		}
+		if depth[n] > maxDepth {
+			maxDepth = depth[n]
+		}
+
+		path = path[:len(path)-1]
+		state[n] = visited
+		return nil
+	}
+
+	for _, n := range nodes {
+		if err := visit(n); err != nil {
+			return err
+		}
+	}
+
+	logf.Log.V(1).Info("Ownership relations", "Edges", edges, "MaxDepth", maxDepth)
+	return nil
+}
+
+// nodeRef returns a human readable reference to the object corresponding to a node, e.g. Machine ns1/m1.
+func nodeRef(n *node) string {
+	return objectRef(n.identity)
+}
+
+// objectRef returns a short human readable reference to an object, e.g. Secret ns1/foo-kubeconfig.
+func objectRef(ref corev1.ObjectReference) string {
+	if ref.Namespace == "" {
+		return fmt.Sprintf("%s %s", ref.Kind, ref.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", ref.Kind, ref.Namespace, ref.Name)
+}
+
 // setClusterTenants sets the cluster tenants for the clusters itself and all their dependent object tree.
 func (o *objectGraph) setClusterTenants() {
 	for _, cluster := range o.getClusters() {
@@ -344,3 +558,109 @@ func (o *objectGraph) setClusterTenant(node, tenant *node) {
 		}
 	}
 }
+
+// filterClusters restricts the object graph to the Clusters selected by the given function and to their dependents; all the other
+// objects are removed from the graph, and thus left untouched in the source management cluster.
+// An error is returned if an object belongs both to a selected and to a non selected Cluster, because moving it would break the
+// Cluster left behind.
+func (o *objectGraph) filterClusters(isSelected func(cluster *node) bool) error {
+	errList := []error{}
+	for uid, node := range o.uidToNode {
+		selected := []string{}
+		notSelected := []string{}
+		for tenant := range node.tenantClusters {
+			if isSelected(tenant) {
+				selected = append(selected, tenant.identity.Name)
+			} else {
+				notSelected = append(notSelected, tenant.identity.Name)
+			}
+		}
+
+		if len(selected) == 0 {
+			delete(o.uidToNode, uid)
+			continue
+		}
+
+		if len(notSelected) > 0 {
+			sort.Strings(selected)
+			sort.Strings(notSelected)
+			errList = append(errList, errors.Errorf("%q %s/%s is shared by the Clusters %s, that are selected for move, and by the Clusters %s, that are not",
+				node.identity.GroupVersionKind(), node.identity.Namespace, node.identity.Name, strings.Join(selected, ", "), strings.Join(notSelected, ", ")))
+		}
+	}
+
+	return kerrors.NewAggregate(errList)
+}
+
+// filterShared restricts the object graph to the shared objects, i.e. the cluster-scoped objects and the namespaced objects with the
+// move-shared label, and to their dependents; shared objects are used as roots of the move sequence in place of the Clusters.
+// All the objects belonging to a Cluster, including the Clusters themselves, are removed from the graph, and thus left untouched
+// in the source management cluster.
+func (o *objectGraph) filterShared() {
+	o.excludeNodes(func(n *node) bool {
+		return len(n.tenantClusters) > 0
+	})
+
+	for _, node := range o.getNodes() {
+		if node.virtual {
+			continue
+		}
+		if _, ok := node.labels[clusterctlv1.ClusterctlMoveSharedLabelName]; ok || node.identity.Namespace == "" {
+			o.setClusterTenant(node, node)
+		}
+	}
+
+	o.excludeNodes(func(n *node) bool {
+		return len(n.tenantClusters) == 0
+	})
+}
+
+// filterRoots restricts the object graph to the objects selected by the given function and to their dependents; the selected
+// objects are used as roots of the move sequence in place of the Clusters. All the other objects, including the Clusters and
+// the other objects the roots depend on, are removed from the graph, and thus left untouched in the source management cluster.
+// The returned map contains, for each removed node, the list of the remaining nodes that were depending on it, if any.
+func (o *objectGraph) filterRoots(isRoot func(n *node) bool) map[*node][]*node {
+	for _, n := range o.getNodes() {
+		n.tenantClusters = make(map[*node]empty)
+	}
+
+	for _, n := range o.getNodes() {
+		if !n.virtual && isRoot(n) {
+			o.setClusterTenant(n, n)
+		}
+	}
+
+	return o.excludeNodes(func(n *node) bool {
+		return len(n.tenantClusters) == 0
+	})
+}
+
+// excludeNodes removes from the object graph the nodes selected by the given function, so the corresponding objects are left
+// untouched in the source management cluster; the ownership relations between the remaining nodes and the excluded ones are dropped.
+// The returned map contains, for each excluded node, the list of the remaining nodes that were depending on it, if any.
+func (o *objectGraph) excludeNodes(isExcluded func(n *node) bool) map[*node][]*node {
+	excluded := map[*node]empty{}
+	for uid, node := range o.uidToNode {
+		if isExcluded(node) {
+			excluded[node] = empty{}
+			delete(o.uidToNode, uid)
+		}
+	}
+
+	dependents := map[*node][]*node{}
+	for _, node := range o.uidToNode {
+		for owner := range node.owners {
+			if _, ok := excluded[owner]; ok {
+				dependents[owner] = append(dependents[owner], node)
+				delete(node.owners, owner)
+			}
+		}
+		for owner := range node.softOwners {
+			if _, ok := excluded[owner]; ok {
+				dependents[owner] = append(dependents[owner], node)
+				delete(node.softOwners, owner)
+			}
+		}
+	}
+	return dependents
+}
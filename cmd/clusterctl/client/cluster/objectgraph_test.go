@@ -17,6 +17,7 @@ limitations under the License.
 package cluster
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"testing"
@@ -24,12 +25,15 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestObjectGraph_getDiscoveryTypeMetaList(t *testing.T) {
@@ -913,6 +917,22 @@ func getFakeDiscoveryTypes(graph *objectGraph) ([]metav1.TypeMeta, error) {
 	return discoveryTypes, nil
 }
 
+// discoverFakeGraph returns an objectGraph bound to a source cluster with the given objects and all the CRDs for the types
+// involved in the test, once the objects in the ns1 namespace are discovered, together with a fakeProxy to an empty target
+// cluster with all the required CRDs.
+func discoverFakeGraph(t *testing.T, objs []runtime.Object) (*objectGraph, *test.FakeProxy) {
+	t.Helper()
+	g := NewWithT(t)
+
+	graph := getObjectGraphWithObjs(objs)
+
+	discoveryTypes, err := getFakeDiscoveryTypes(graph)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(graph.Discovery("ns1", discoveryTypes)).To(Succeed())
+
+	return graph, getFakeProxyWithCRDs()
+}
+
 func TestObjectGraph_Discovery(t *testing.T) {
 	g := NewWithT(t)
 	// NB. we are testing the graph is properly built starting from objects (TestGraphBuilder_addObj_WithFakeObjects) or from the same objects read from the cluster (this test).
@@ -1055,6 +1075,146 @@ func TestObjectGraph_DiscoveryByNamespace(t *testing.T) {
 	}
 }
 
+func TestObjectGraph_DiscoveryInNamespaces(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound to a source cluster with three clusters in different namespaces.
+	objs := []runtime.Object{}
+	objs = append(objs, test.NewFakeCluster("ns1", "cluster1").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns2", "cluster1").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns3", "cluster1").Objs()...)
+	graph := getObjectGraphWithObjs(objs)
+
+	// Get all the types to be considered for discovery
+	discoveryTypes, err := getFakeDiscoveryTypes(graph)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Read only from ns1 and ns3.
+	g.Expect(graph.DiscoveryInNamespaces([]string{"ns1", "ns3"}, discoveryTypes)).To(Succeed())
+
+	clusters := []string{}
+	for _, cluster := range graph.getClusters() {
+		clusters = append(clusters, fmt.Sprintf("%s/%s", cluster.identity.Namespace, cluster.identity.Name))
+	}
+	g.Expect(clusters).To(ConsistOf("ns1/cluster1", "ns3/cluster1"))
+	g.Expect(graph.uidToNode).To(HaveLen(8))
+}
+
+// noMatchProxy wraps a Proxy, returning clients that fail listing the objects of a kind as if the kind was not served.
+type noMatchProxy struct {
+	Proxy
+	listKind string
+}
+
+func (p *noMatchProxy) NewClient() (client.Client, error) {
+	c, err := p.Proxy.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	return &noMatchClient{Client: c, listKind: p.listKind}, nil
+}
+
+type noMatchClient struct {
+	client.Client
+	listKind string
+}
+
+func (c *noMatchClient) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	gvk := list.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == c.listKind {
+		return &meta.NoKindMatchError{GroupKind: gvk.GroupKind(), SearchedVersions: []string{gvk.Version}}
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func TestObjectGraph_DiscoveryInNamespaces_kindNotServed(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound to a source cluster where the DummyInfrastructureCluster kind is not served.
+	graph := getObjectGraphWithObjs(test.NewFakeCluster("ns1", "cluster1").Objs())
+	discoveryTypes, err := getFakeDiscoveryTypes(graph)
+	g.Expect(err).NotTo(HaveOccurred())
+	proxy := &noMatchProxy{Proxy: graph.proxy, listKind: "DummyInfrastructureClusterList"}
+
+	// Fails if strict.
+	strictGraph := newObjectGraph(proxy)
+	strictGraph.strictDiscovery = true
+	g.Expect(strictGraph.DiscoveryInNamespaces([]string{"ns1"}, discoveryTypes)).ToNot(Succeed())
+
+	// Otherwise the kind is skipped, and the other objects are discovered.
+	graph = newObjectGraph(proxy)
+	g.Expect(graph.DiscoveryInNamespaces([]string{"ns1"}, discoveryTypes)).To(Succeed())
+	g.Expect(graph.skippedKinds).To(ConsistOf("DummyInfrastructureClusterList.infrastructure.cluster.x-k8s.io"))
+	g.Expect(graph.getClusters()).To(HaveLen(1))
+	for _, n := range graph.getNodes() {
+		g.Expect(n.identity.Kind).NotTo(Equal("DummyInfrastructureCluster"))
+	}
+}
+
+func Test_objectGraph_checkOwnership(t *testing.T) {
+	// newConfigMap returns a ConfigMap owned by the ConfigMaps with the given names.
+	newConfigMap := func(name string, owners ...string) unstructured.Unstructured {
+		obj := unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace("ns1")
+		obj.SetName(name)
+		obj.SetUID(types.UID(name))
+		ownerReferences := []metav1.OwnerReference{}
+		for _, owner := range owners {
+			ownerReferences = append(ownerReferences, metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: owner, UID: types.UID(owner)})
+		}
+		obj.SetOwnerReferences(ownerReferences)
+		return obj
+	}
+
+	tests := []struct {
+		name    string
+		objs    []unstructured.Unstructured
+		wantErr string
+	}{
+		{
+			name: "An ownership chain",
+			objs: []unstructured.Unstructured{
+				newConfigMap("a"),
+				newConfigMap("b", "a"),
+				newConfigMap("c", "a", "b"),
+			},
+		},
+		{
+			name: "An object owning itself",
+			objs: []unstructured.Unstructured{
+				newConfigMap("a", "a"),
+			},
+			wantErr: "found a cycle in the owner references, each object is owned by the next one: ConfigMap ns1/a -> ConfigMap ns1/a",
+		},
+		{
+			name: "A cycle across many objects",
+			objs: []unstructured.Unstructured{
+				newConfigMap("a", "c"),
+				newConfigMap("b", "a"),
+				newConfigMap("c", "b"),
+				newConfigMap("d", "c"),
+			},
+			wantErr: "found a cycle in the owner references, each object is owned by the next one: ConfigMap ns1/a -> ConfigMap ns1/c -> ConfigMap ns1/b -> ConfigMap ns1/a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			graph := newObjectGraph(nil)
+			err := graph.addRestoredObjs(tt.objs)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				g.Expect(errors.Is(err, ErrDependencyCycle)).To(BeTrue())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
 func Test_objectGraph_setSoftOwnership(t *testing.T) {
 	g := NewWithT(t)
 
@@ -1270,3 +1430,127 @@ func Test_objectGraph_setClusterTenants(t *testing.T) {
 		})
 	}
 }
+
+func Test_objectGraph_filterClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	type fields struct {
+		objs []runtime.Object
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		clusterName string
+		wantNodes   []string
+		wantErr     bool
+	}{
+		{
+			name: "Two clusters, select one",
+			fields: fields{
+				objs: func() []runtime.Object {
+					objs := []runtime.Object{}
+					objs = append(objs, test.NewFakeCluster("ns1", "foo").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "bar").Objs()...)
+					return objs
+				}(),
+			},
+			clusterName: "foo",
+			wantNodes: []string{
+				"cluster.x-k8s.io/v1alpha3, Kind=Cluster, ns1/foo",
+				"infrastructure.cluster.x-k8s.io/v1alpha3, Kind=DummyInfrastructureCluster, ns1/foo",
+				"/v1, Kind=Secret, ns1/foo-ca",
+				"/v1, Kind=Secret, ns1/foo-kubeconfig",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Two clusters with a shared object, select one",
+			fields: fields{
+				objs: func() []runtime.Object {
+					sharedInfrastructureTemplate := test.NewFakeInfrastructureTemplate("shared")
+
+					objs := []runtime.Object{
+						sharedInfrastructureTemplate,
+					}
+
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster1").
+						WithMachineSets(
+							test.NewFakeMachineSet("cluster1-ms1").
+								WithInfrastructureTemplate(sharedInfrastructureTemplate),
+						).Objs()...)
+
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster2").
+						WithMachineSets(
+							test.NewFakeMachineSet("cluster2-ms1").
+								WithInfrastructureTemplate(sharedInfrastructureTemplate),
+						).Objs()...)
+
+					return objs
+				}(),
+			},
+			clusterName: "cluster1",
+			wantErr:     true, // the shared object can't be moved without breaking cluster2
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gb, err := getDetachedObjectGraphWihObjs(tt.fields.objs)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			gb.setSoftOwnership()
+			gb.setClusterTenants()
+
+			err = gb.filterClusters(func(cluster *node) bool {
+				return cluster.identity.Name == tt.clusterName
+			})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			gotNodes := []string{}
+			for _, node := range gb.uidToNode {
+				gotNodes = append(gotNodes, string(node.identity.UID))
+			}
+
+			g.Expect(gotNodes).To(ConsistOf(tt.wantNodes))
+		})
+	}
+}
+
+func Test_objectGraph_filterShared(t *testing.T) {
+	g := NewWithT(t)
+
+	newTemplate := func(namespace, name string, labels map[string]string) runtime.Object {
+		template := test.NewFakeInfrastructureTemplate(name)
+		template.Namespace = namespace
+		template.Labels = labels
+		template.UID = types.UID(fmt.Sprintf("%s, %s/%s", template.GroupVersionKind().String(), namespace, name))
+		return template
+	}
+
+	objs := []runtime.Object{
+		newTemplate("ns1", "shared", map[string]string{clusterctlv1.ClusterctlMoveSharedLabelName: ""}),
+		newTemplate("ns1", "other", nil),
+		newTemplate("", "global", nil),
+	}
+	objs = append(objs, test.NewFakeCluster("ns1", "foo").Objs()...)
+
+	gb, err := getDetachedObjectGraphWihObjs(objs)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	gb.setSoftOwnership()
+	gb.setClusterTenants()
+	gb.filterShared()
+
+	gotNodes := []string{}
+	for _, node := range gb.uidToNode {
+		gotNodes = append(gotNodes, string(node.identity.UID))
+	}
+
+	g.Expect(gotNodes).To(ConsistOf(
+		"infrastructure.cluster.x-k8s.io/v1alpha3, Kind=DummyInfrastructureMachineTemplate, ns1/shared",
+		"infrastructure.cluster.x-k8s.io/v1alpha3, Kind=DummyInfrastructureMachineTemplate, /global",
+	))
+}
@@ -18,6 +18,7 @@ package cluster
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -37,31 +38,97 @@ var (
 )
 
 type proxy struct {
-	kubeconfig string
+	kubeconfig Kubeconfig
 }
 
 var _ Proxy = &proxy{}
 
 func (k *proxy) CurrentNamespace() (string, error) {
-	config, err := clientcmd.LoadFromFile(k.kubeconfig)
+	namespace, err := k.ContextNamespace()
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to load Kubeconfig file from %q", k.kubeconfig)
+		return "", err
 	}
 
-	if config.CurrentContext == "" {
-		return "", errors.Wrapf(err, "failed to get current-context from %q", k.kubeconfig)
+	if namespace != "" {
+		return namespace, nil
+	}
+
+	return "default", nil
+}
+
+func (k *proxy) ContextNamespace() (string, error) {
+	config, err := clientcmd.LoadFromFile(k.kubeconfig.Path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to load Kubeconfig file from %q", k.kubeconfig.Path)
+	}
+
+	// If a context is defined, use it instead of the current context.
+	context := config.CurrentContext
+	if k.kubeconfig.Context != "" {
+		context = k.kubeconfig.Context
 	}
 
-	v, ok := config.Contexts[config.CurrentContext]
+	if context == "" {
+		return "", errors.Errorf("failed to get current-context from %q", k.kubeconfig.Path)
+	}
+
+	v, ok := config.Contexts[context]
 	if !ok {
-		return "", errors.Wrapf(err, "failed to get context %q from %q", config.CurrentContext, k.kubeconfig)
+		return "", errors.Errorf("failed to get context %q from %q", context, k.kubeconfig.Path)
 	}
 
-	if v.Namespace != "" {
-		return v.Namespace, nil
+	return v.Namespace, nil
+}
+
+// kubeconfigContextName returns the name of the context used for connecting to a cluster, i.e. the context defined in the
+// Kubeconfig or, if not defined, the current context of the kubeconfig file.
+func kubeconfigContextName(kubeconfig Kubeconfig) (string, error) {
+	if kubeconfig.Context != "" {
+		return kubeconfig.Context, nil
 	}
 
-	return "default", nil
+	config, err := clientcmd.LoadFromFile(kubeconfig.Path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to load Kubeconfig file from %q", kubeconfig.Path)
+	}
+	if config.CurrentContext == "" {
+		return "", errors.Errorf("failed to get current-context from %q", kubeconfig.Path)
+	}
+	return config.CurrentContext, nil
+}
+
+// kubeconfigServer returns the name of the context and the URL of the API server used for connecting to a cluster, e.g. for
+// identifying the cluster in logs. Credentials are never returned, including the user info embedded in the server URL, if any.
+func kubeconfigServer(kubeconfig Kubeconfig) (string, string, error) {
+	path := kubeconfig.Path
+	if path == "" {
+		path = clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
+	}
+
+	config, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to load Kubeconfig file from %q", path)
+	}
+
+	context := config.CurrentContext
+	if kubeconfig.Context != "" {
+		context = kubeconfig.Context
+	}
+	v, ok := config.Contexts[context]
+	if !ok {
+		return "", "", errors.Errorf("failed to get context %q from %q", context, path)
+	}
+	c, ok := config.Clusters[v.Cluster]
+	if !ok {
+		return "", "", errors.Errorf("failed to get cluster %q from %q", v.Cluster, path)
+	}
+
+	server, err := url.Parse(c.Server)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to parse the server URL of cluster %q from %q", v.Cluster, path)
+	}
+	server.User = nil
+	return context, server.String(), nil
 }
 
 func (k *proxy) NewClient() (client.Client, error) {
@@ -141,10 +208,10 @@ func listObjByGVK(c client.Client, groupVersion, kind string, options []client.L
 	return objList, nil
 }
 
-func newProxy(kubeconfig string) Proxy {
+func newProxy(kubeconfig Kubeconfig) Proxy {
 	// If a kubeconfig file isn't provided, find one in the standard locations.
-	if kubeconfig == "" {
-		kubeconfig = clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
+	if kubeconfig.Path == "" {
+		kubeconfig.Path = clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
 	}
 	return &proxy{
 		kubeconfig: kubeconfig,
@@ -152,12 +219,13 @@ func newProxy(kubeconfig string) Proxy {
 }
 
 func (k *proxy) getConfig() (*rest.Config, error) {
-	config, err := clientcmd.LoadFromFile(k.kubeconfig)
+	config, err := clientcmd.LoadFromFile(k.kubeconfig.Path)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to load Kubeconfig file from %q", k.kubeconfig)
+		return nil, errors.Wrapf(err, "failed to load Kubeconfig file from %q", k.kubeconfig.Path)
 	}
 
-	restConfig, err := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{}).ClientConfig()
+	// If a context is defined, use it instead of the current context.
+	restConfig, err := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{CurrentContext: k.kubeconfig.Context}).ClientConfig()
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "invalid configuration:") {
 			return nil, errors.New(strings.Replace(err.Error(), "invalid configuration:", "invalid kubeconfig file; clusterctl requires a valid kubeconfig file to connect to the management cluster:", 1))
@@ -149,7 +149,7 @@ func (c *clusterctlClient) GetClusterTemplate(options GetClusterTemplateOptions)
 	}
 
 	// Gets  the client for the current management cluster
-	cluster, err := c.clusterClientFactory(options.Kubeconfig)
+	cluster, err := c.clusterClientFactory(cluster.Kubeconfig{Path: options.Kubeconfig})
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +60,7 @@ type DeleteOptions struct {
 }
 
 func (c *clusterctlClient) Delete(options DeleteOptions) error {
-	clusterClient, err := c.clusterClientFactory(options.Kubeconfig)
+	clusterClient, err := c.clusterClientFactory(cluster.Kubeconfig{Path: options.Kubeconfig})
 	if err != nil {
 		return err
 	}
@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/util"
@@ -327,7 +328,7 @@ func Test_clusterctlClient_Init(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 
 			if tt.field.hasCRD {
-				g.Expect(tt.field.client.clusters["kubeconfig"].ProviderInventory().EnsureCustomResourceDefinitions()).To(Succeed())
+				g.Expect(tt.field.client.clusters[cluster.Kubeconfig{Path: "kubeconfig"}].ProviderInventory().EnsureCustomResourceDefinitions()).To(Succeed())
 			}
 
 			got, err := tt.field.client.Init(InitOptions{
@@ -436,7 +437,7 @@ func fakeEmptyCluster() *fakeClient {
 		}).
 		WithFile("v3.0.0", "cluster-template.yaml", templateYAML("ns4", "test"))
 
-	cluster1 := newFakeCluster("kubeconfig", config1).
+	cluster1 := newFakeCluster(cluster.Kubeconfig{Path: "kubeconfig"}, config1).
 		// fake repository for capi, bootstrap and infra provider (matching provider's config)
 		WithRepository(repository1).
 		WithRepository(repository2).
@@ -459,7 +460,7 @@ func fakeEmptyCluster() *fakeClient {
 func fakeInitializedCluster() *fakeClient {
 	client := fakeEmptyCluster()
 
-	p := client.clusters["kubeconfig"].Proxy()
+	p := client.clusters[cluster.Kubeconfig{Path: "kubeconfig"}].Proxy()
 	fp := p.(*test.FakeProxy)
 
 	fp.WithProviderInventory(capiProviderConfig.Name(), capiProviderConfig.Type(), "v1.0.0", "capi-system", "")
@@ -16,41 +16,431 @@ limitations under the License.
 
 package client
 
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
 func (c *clusterctlClient) Move(options MoveOptions) error {
+	_, err := c.MoveWithReport(options)
+	return err
+}
+
+func (c *clusterctlClient) MoveWithReport(options MoveOptions) (*MoveReport, error) {
+	// Objects are saved to, or restored from, either a directory or an archive, which are otherwise handled the same way.
+	if options.ToDirectory != "" && options.ToArchive != "" {
+		return nil, errors.New("ToDirectory and ToArchive can't be set at the same time")
+	}
+	if options.FromDirectory != "" && options.FromArchive != "" {
+		return nil, errors.New("FromDirectory and FromArchive can't be set at the same time")
+	}
+	toBackup := options.ToDirectory != "" || options.ToArchive != ""
+	fromBackup := options.FromDirectory != "" || options.FromArchive != ""
+
+	// Objects can be moved either to a target management cluster or to a directory, not both.
+	if (options.ToKubeconfig != "" || options.ToKubeconfigContext != "") && toBackup {
+		return nil, errors.New("ToKubeconfig and ToKubeconfigContext can't be set together with ToDirectory or ToArchive")
+	}
+
+	// The kubeconfig of the target management cluster is read either from a file or from a Secret in the source management cluster.
+	if options.ToKubeconfigSecret != "" && (options.ToKubeconfig != "" || toBackup || fromBackup || options.PauseOnly || options.UnpauseOnly || options.ListObjects) {
+		return nil, errors.New("ToKubeconfigSecret can't be set together with ToKubeconfig, ToDirectory, ToArchive, FromDirectory, FromArchive, PauseOnly, UnpauseOnly or ListObjects")
+	}
+
+	// Objects saved to a directory keep their namespace; remapping happens when restoring them.
+	if options.ToNamespace != "" && toBackup {
+		return nil, errors.New("ToNamespace can't be set when moving objects to a directory or an archive")
+	}
+
+	// References to other namespaces are remapped only when moving objects to a different target namespace.
+	if len(options.RewriteRefs) > 0 && options.ToNamespace == "" {
+		return nil, errors.New("RewriteRefs can be set only together with ToNamespace")
+	}
+
+	// Finalizers are rewritten only when creating objects in a target management cluster.
+	if len(options.RewriteFinalizers) > 0 && (toBackup || options.PauseOnly || options.UnpauseOnly) {
+		return nil, errors.New("RewriteFinalizers can't be set together with ToDirectory, ToArchive, PauseOnly or UnpauseOnly")
+	}
+
+	// Clusters are renamed only when creating objects in a target management cluster.
+	if len(options.RenameClusters) > 0 && (toBackup || options.PauseOnly || options.UnpauseOnly || options.ListObjects) {
+		return nil, errors.New("RenameClusters can't be set together with ToDirectory, ToArchive, PauseOnly, UnpauseOnly or ListObjects")
+	}
+
+	// Checksums are verified only when restoring objects from a directory.
+	if options.SkipVerify && !fromBackup {
+		return nil, errors.New("SkipVerify can be set only when restoring objects from a directory or an archive")
+	}
+
+	// Preflight checks require a target management cluster.
+	if options.ValidateOnly && (options.DryRun || options.StateFile != "" || toBackup || fromBackup) {
+		return nil, errors.New("ValidateOnly can't be set together with DryRun, StateFile, ToDirectory, ToArchive, FromDirectory or FromArchive")
+	}
+
+	// Listing objects happens only in the source management cluster.
+	if options.ListObjects && (options.ToKubeconfig != "" || options.ToKubeconfigContext != "" || toBackup || fromBackup || options.ValidateOnly || options.DryRun || options.StateFile != "" || options.PauseOnly || options.UnpauseOnly) {
+		return nil, errors.New("ListObjects can't be set together with ToKubeconfig, ToKubeconfigContext, ToDirectory, ToArchive, FromDirectory, FromArchive, ValidateOnly, DryRun, StateFile, PauseOnly or UnpauseOnly")
+	}
+
+	// Progress is recorded only when moving objects between management clusters.
+	if options.StateFile != "" && (toBackup || fromBackup) {
+		return nil, errors.New("StateFile can't be set when moving objects to or from a directory or an archive")
+	}
+	if options.Resume && options.StateFile == "" {
+		return nil, errors.New("StateFile must be set for resuming an interrupted move")
+	}
+
+	// Pausing or resuming the Clusters happens only in the source management cluster.
+	if options.PauseOnly && options.UnpauseOnly {
+		return nil, errors.New("PauseOnly and UnpauseOnly can't be set at the same time")
+	}
+	if (options.PauseOnly || options.UnpauseOnly) && (options.ToKubeconfig != "" || options.ToKubeconfigContext != "" || toBackup || fromBackup || options.ValidateOnly || options.StateFile != "" || options.ToNamespace != "") {
+		return nil, errors.New("PauseOnly and UnpauseOnly can't be set together with ToKubeconfig, ToKubeconfigContext, ToDirectory, ToArchive, FromDirectory, FromArchive, ValidateOnly, StateFile or ToNamespace")
+	}
+
+	// Provisioning can be verified only when moving objects to a target management cluster.
+	if options.WaitForCompletion && (options.DryRun || options.ValidateOnly || toBackup || options.PauseOnly || options.UnpauseOnly) {
+		return nil, errors.New("WaitForCompletion can't be set together with DryRun, ValidateOnly, ToDirectory, ToArchive, PauseOnly or UnpauseOnly")
+	}
+
+	// Objects are moved either from a single namespace or from a list of namespaces; only objects from a single namespace can be
+	// moved to a different target namespace.
+	if options.Namespace != "" && len(options.Namespaces) > 0 {
+		return nil, errors.New("Namespace and Namespaces can't be set at the same time")
+	}
+	if options.ToNamespace != "" && len(options.Namespaces) > 0 {
+		return nil, errors.New("ToNamespace can't be set together with Namespaces")
+	}
+	if options.AllNamespaces && (options.Namespace != "" || len(options.Namespaces) > 0 || options.ToNamespace != "") {
+		return nil, errors.New("AllNamespaces can't be set together with Namespace, Namespaces or ToNamespace")
+	}
+	if options.FromContextNamespace && (options.Namespace != "" || len(options.Namespaces) > 0 || options.AllNamespaces) {
+		return nil, errors.New("FromContextNamespace can't be set together with Namespace, Namespaces or AllNamespaces")
+	}
+
+	// Shared objects do not belong to any Cluster.
+	if options.SharedOnly && (options.ClusterName != "" || options.LabelSelector != "") {
+		return nil, errors.New("SharedOnly can't be set together with ClusterName or LabelSelector")
+	}
+	if options.RootKind != "" && (options.SharedOnly || fromBackup || options.PauseOnly || options.UnpauseOnly) {
+		return nil, errors.New("RootKind can't be set together with SharedOnly, FromDirectory, FromArchive, PauseOnly or UnpauseOnly")
+	}
+
+	// Objects restored from a directory or an archive are not discovered in a source management cluster.
+	if options.StrictDiscovery && fromBackup {
+		return nil, errors.New("StrictDiscovery can't be set together with FromDirectory or FromArchive")
+	}
+
+	// Rejects invalid label selectors before starting the move operation.
+	if options.LabelSelector != "" {
+		if _, err := labels.Parse(options.LabelSelector); err != nil {
+			return nil, errors.Wrapf(err, "invalid label selector %q", options.LabelSelector)
+		}
+	}
+
+	// Externally managed infrastructure is only left behind when moving objects.
+	if options.ExternalInfrastructure && (options.PauseOnly || options.UnpauseOnly) {
+		return nil, errors.New("ExternalInfrastructure can't be set together with PauseOnly or UnpauseOnly")
+	}
+
+	// Verifying objects is supported only when creating objects in a target management cluster.
+	if options.VerifyObjects && (toBackup || options.PauseOnly || options.UnpauseOnly) {
+		return nil, errors.New("VerifyObjects can't be set together with ToDirectory, ToArchive, PauseOnly or UnpauseOnly")
+	}
+
+	// Transforming objects is supported only when creating objects in a target management cluster.
+	if (len(options.Transformers) > 0 || options.TransformFile != "") && (toBackup || options.PauseOnly || options.UnpauseOnly) {
+		return nil, errors.New("Transformers and TransformFile can't be set together with ToDirectory, ToArchive, PauseOnly or UnpauseOnly")
+	}
+	// Copying objects leaves them in the source management cluster, so both management clusters reconcile the same infrastructure
+	// unless the objects are transformed, e.g. to use different credentials.
+	if options.CopyOnly && (toBackup || fromBackup || options.PauseOnly || options.UnpauseOnly) {
+		return nil, errors.New("CopyOnly can't be set together with ToDirectory, ToArchive, FromDirectory, FromArchive, PauseOnly or UnpauseOnly")
+	}
+	if options.CopyOnly && len(options.Transformers) == 0 && options.TransformFile == "" && !options.AllowUnsafeCopy {
+		return nil, errors.New("CopyOnly requires Transformers or TransformFile for changing the copied objects, e.g. their credentials, or AllowUnsafeCopy")
+	}
+	if options.AllowUnsafeCopy && !options.CopyOnly {
+		return nil, errors.New("AllowUnsafeCopy can be set only together with CopyOnly")
+	}
+
+	// Clusters saved to a directory or an archive are always paused, so their original paused state is not known when restoring them.
+	if options.PreservePaused && (fromBackup || options.PauseOnly || options.UnpauseOnly) {
+		return nil, errors.New("PreservePaused can't be set together with FromDirectory, FromArchive, PauseOnly or UnpauseOnly")
+	}
+
+	// Zero means using the default, while negative values are rejected.
+	if options.Parallelism < 0 || options.Retries < 0 {
+		return nil, errors.New("Parallelism and Retries can't be negative")
+	}
+	if options.Timeout < 0 || options.TargetReadyTimeout < 0 || options.DeleteTimeout < 0 || options.RetryBackoff < 0 || options.WaitForCompletionTimeout < 0 {
+		return nil, errors.New("Timeout, TargetReadyTimeout, DeleteTimeout, RetryBackoff and WaitForCompletionTimeout can't be negative")
+	}
+
+	transformers, err := getObjectTransformers(options.Transformers, options.TransformFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// If a source directory or archive is defined, restore the objects from there instead of moving them from a source management cluster.
+	if fromBackup {
+		return c.fromBackup(options, transformers)
+	}
+
 	// Get the client for interacting with the source management cluster.
-	fromCluster, err := c.clusterClientFactory(options.FromKubeconfig)
+	fromCluster, err := c.clusterClientFactory(cluster.Kubeconfig{Path: options.FromKubeconfig, Context: options.FromKubeconfigContext})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Ensures the custom resource definitions required by clusterctl are in place.
 	if err := fromCluster.ProviderInventory().EnsureCustomResourceDefinitions(); err != nil {
-		return err
+		return nil, err
+	}
+
+	// If the options specifying the Namespace or the Namespaces are empty, try to detect it.
+	if options.Namespace == "" && len(options.Namespaces) == 0 {
+		currentNamespace, err := getContextNamespace(fromCluster.Proxy(), options.AllNamespaces, options.FromContextNamespace)
+		if err != nil {
+			return nil, err
+		}
+		options.Namespace = currentNamespace
+	}
+
+	moveOptions := cluster.MoveOptions{
+		Namespace:                options.Namespace,
+		Namespaces:               options.Namespaces,
+		ClusterName:              options.ClusterName,
+		LabelSelector:            options.LabelSelector,
+		SharedOnly:               options.SharedOnly,
+		RootKind:                 options.RootKind,
+		ExcludeKinds:             options.ExcludeKinds,
+		StrictDiscovery:          options.StrictDiscovery,
+		ExternalInfrastructure:   options.ExternalInfrastructure,
+		Parallelism:              options.Parallelism,
+		StateFile:                options.StateFile,
+		Resume:                   options.Resume,
+		Timeout:                  options.Timeout,
+		ToNamespace:              options.ToNamespace,
+		RewriteRefs:              options.RewriteRefs,
+		RewriteFinalizers:        options.RewriteFinalizers,
+		RenameClusters:           options.RenameClusters,
+		ValidateOnly:             options.ValidateOnly,
+		TargetReadyTimeout:       options.TargetReadyTimeout,
+		DeleteTimeout:            options.DeleteTimeout,
+		SkipExisting:             options.SkipExisting,
+		VerifyObjects:            options.VerifyObjects,
+		AnnotateProvenance:       options.AnnotateProvenance,
+		MovedFromAnnotation:      options.MovedFromAnnotation,
+		MovedAtAnnotation:        options.MovedAtAnnotation,
+		GraphOutput:              options.GraphOutput,
+		Retries:                  options.Retries,
+		RetryBackoff:             options.RetryBackoff,
+		ContinueOnError:          options.ContinueOnError,
+		CopyOnly:                 options.CopyOnly,
+		PreservePaused:           options.PreservePaused,
+		Transformers:             transformers,
+		WaitForCompletion:        options.WaitForCompletion,
+		WaitForCompletionTimeout: options.WaitForCompletionTimeout,
+		DryRun:                   options.DryRun,
+	}
+
+	// If only pausing or resuming the Clusters, stop before accessing the target management cluster.
+	if options.PauseOnly || options.UnpauseOnly {
+		return toMoveReport(fromCluster.ObjectMover().SetPaused(options.PauseOnly, moveOptions))
+	}
+
+	// If only listing the objects, stop after discovering them.
+	if options.ListObjects {
+		return toMoveReport(fromCluster.ObjectMover().ListObjects(moveOptions))
+	}
+
+	// If a target directory or archive is defined, save the objects there instead of moving them to a target management cluster.
+	if options.ToDirectory != "" {
+		return toMoveReport(fromCluster.ObjectMover().ToDirectory(options.ToDirectory, moveOptions))
+	}
+	if options.ToArchive != "" {
+		return toMoveReport(fromCluster.ObjectMover().ToArchive(options.ToArchive, moveOptions))
 	}
 
 	// Get the client for interacting with the target management cluster.
-	toCluster, err := c.clusterClientFactory(options.ToKubeconfig)
+	// Nb. when running in dry-run mode the target management cluster is not required.
+	var toCluster cluster.Client
+	if !options.DryRun {
+		toKubeconfig := cluster.Kubeconfig{Path: options.ToKubeconfig, Context: options.ToKubeconfigContext}
+
+		// If required, reads the kubeconfig of the target management cluster from a Secret in the source management cluster.
+		if options.ToKubeconfigSecret != "" {
+			path, err := writeKubeconfigFromSecret(fromCluster.Proxy(), options.ToKubeconfigSecret)
+			if err != nil {
+				return nil, err
+			}
+			defer os.Remove(path)
+			toKubeconfig.Path = path
+		}
+
+		toCluster, err = c.clusterClientFactory(toKubeconfig)
+		if err != nil {
+			return nil, err
+		}
+
+		// Ensures the custom resource definitions required by clusterctl are in place
+		if err := toCluster.ProviderInventory().EnsureCustomResourceDefinitions(); err != nil {
+			return nil, err
+		}
+	}
+
+	return toMoveReport(fromCluster.ObjectMover().Move(toCluster, moveOptions))
+}
+
+// fromBackup restores the objects saved in a directory or in an archive to the target management cluster.
+func (c *clusterctlClient) fromBackup(options MoveOptions, transformers []cluster.ObjectTransformer) (*MoveReport, error) {
+	// There is no source management cluster when restoring objects from a directory or an archive.
+	if options.FromKubeconfig != "" || options.FromKubeconfigContext != "" {
+		return nil, errors.New("FromKubeconfig and FromKubeconfigContext can't be set together with FromDirectory or FromArchive")
+	}
+	if options.ToDirectory != "" || options.ToArchive != "" {
+		return nil, errors.New("ToDirectory and ToArchive can't be set together with FromDirectory or FromArchive")
+	}
+
+	// Get the client for interacting with the target management cluster.
+	toCluster, err := c.clusterClientFactory(cluster.Kubeconfig{Path: options.ToKubeconfig, Context: options.ToKubeconfigContext})
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Ensures the custom resource definitions required by clusterctl are in place.
+	// Nb. when running in dry-run mode the target management cluster is not accessed.
+	if !options.DryRun {
+		if err := toCluster.ProviderInventory().EnsureCustomResourceDefinitions(); err != nil {
+			return nil, err
+		}
+	}
+
+	moveOptions := cluster.MoveOptions{
+		ClusterName:              options.ClusterName,
+		LabelSelector:            options.LabelSelector,
+		SharedOnly:               options.SharedOnly,
+		ExcludeKinds:             options.ExcludeKinds,
+		ExternalInfrastructure:   options.ExternalInfrastructure,
+		Parallelism:              options.Parallelism,
+		Timeout:                  options.Timeout,
+		ToNamespace:              options.ToNamespace,
+		RewriteRefs:              options.RewriteRefs,
+		RewriteFinalizers:        options.RewriteFinalizers,
+		RenameClusters:           options.RenameClusters,
+		TargetReadyTimeout:       options.TargetReadyTimeout,
+		SkipVerify:               options.SkipVerify,
+		SkipExisting:             options.SkipExisting,
+		VerifyObjects:            options.VerifyObjects,
+		AnnotateProvenance:       options.AnnotateProvenance,
+		MovedFromAnnotation:      options.MovedFromAnnotation,
+		MovedAtAnnotation:        options.MovedAtAnnotation,
+		GraphOutput:              options.GraphOutput,
+		Retries:                  options.Retries,
+		RetryBackoff:             options.RetryBackoff,
+		ContinueOnError:          options.ContinueOnError,
+		Transformers:             transformers,
+		WaitForCompletion:        options.WaitForCompletion,
+		WaitForCompletionTimeout: options.WaitForCompletionTimeout,
+		DryRun:                   options.DryRun,
 	}
 
-	// Ensures the custom resource definitions required by clusterctl are in place
-	if err := toCluster.ProviderInventory().EnsureCustomResourceDefinitions(); err != nil {
-		return err
+	if options.FromArchive != "" {
+		return toMoveReport(toCluster.ObjectMover().FromArchive(toCluster, options.FromArchive, moveOptions))
 	}
+	return toMoveReport(toCluster.ObjectMover().FromDirectory(toCluster, options.FromDirectory, moveOptions))
+}
 
-	// If the option specifying the Namespace is empty, try to detect it.
-	if options.Namespace == "" {
-		currentNamespace, err := fromCluster.Proxy().CurrentNamespace()
+// getObjectTransformers converts the transformers defined in the high-level library into the transformers used by the low-level
+// library, adding the transformer applying the rules defined in transformFile, if any.
+func getObjectTransformers(transformers []ObjectTransformer, transformFile string) ([]cluster.ObjectTransformer, error) {
+	ret := make([]cluster.ObjectTransformer, 0, len(transformers)+1)
+	for _, t := range transformers {
+		ret = append(ret, cluster.ObjectTransformer(t))
+	}
+	if transformFile != "" {
+		t, err := cluster.NewObjectTransformerFromFile(transformFile)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		options.Namespace = currentNamespace
+		ret = append(ret, t)
+	}
+	return ret, nil
+}
+
+// toMoveReport converts the summary returned by the low-level library into a MoveReport.
+// Nb. The summary of a failed move is returned together with the error, if any.
+func toMoveReport(summary *cluster.MoveSummary, err error) (*MoveReport, error) {
+	if summary == nil {
+		return nil, err
+	}
+	return (*MoveReport)(summary), err
+}
+
+// writeKubeconfigFromSecret writes to a temporary file the kubeconfig stored in a Secret, in the namespace/name format, following
+// the Cluster API convention for the kubeconfig of the workload clusters, and returns the path of the file.
+// Nb. The file is readable only by the current user; the caller is responsible for removing it.
+func writeKubeconfigFromSecret(proxy cluster.Proxy, secretRef string) (string, error) {
+	parts := strings.Split(secretRef, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", errors.Errorf("invalid kubeconfig Secret %q, the namespace/name format is required", secretRef)
 	}
+	key := types.NamespacedName{Namespace: parts[0], Name: parts[1]}
 
-	if err := fromCluster.ObjectMover().Move(options.Namespace, toCluster); err != nil {
-		return err
+	c, err := proxy.NewClient()
+	if err != nil {
+		return "", err
+	}
+	kubeconfigSecret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), key, kubeconfigSecret); err != nil {
+		return "", errors.Wrapf(err, "failed to get the kubeconfig Secret %s", secretRef)
+	}
+	data, ok := kubeconfigSecret.Data[secret.KubeconfigDataName]
+	if !ok || len(data) == 0 {
+		return "", errors.Errorf("the kubeconfig Secret %s does not contain the %q key", secretRef, secret.KubeconfigDataName)
 	}
 
-	return nil
+	f, err := ioutil.TempFile("", "clusterctl-move-kubeconfig-")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create a temporary file for the kubeconfig")
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		os.Remove(f.Name())
+		return "", errors.Wrap(err, "failed to write the kubeconfig to a temporary file")
+	}
+	return f.Name(), nil
+}
+
+// getContextNamespace returns the namespace defined in the current context of the source kubeconfig, used when no namespace
+// is explicitly selected, or an empty string, meaning all the namespaces, if allNamespaces is set.
+// If the context does not define a namespace, the default namespace is returned, unless the namespace of the context was
+// explicitly requested by fromContext.
+func getContextNamespace(proxy cluster.Proxy, allNamespaces, fromContext bool) (string, error) {
+	if allNamespaces {
+		return "", nil
+	}
+
+	namespace, err := proxy.ContextNamespace()
+	if err != nil {
+		return "", err
+	}
+	if namespace != "" {
+		return namespace, nil
+	}
+	if fromContext {
+		return "", errors.New("the current context of the source kubeconfig does not define a namespace; please select the namespace to use, or request explicitly to use all the namespaces")
+	}
+	return metav1.NamespaceDefault, nil
 }
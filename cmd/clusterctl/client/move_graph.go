@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+func (c *clusterctlClient) DiscoverMoveGraph(options DiscoverMoveGraphOptions) (*MoveGraph, error) {
+	// Objects are discovered either in a single namespace or in a list of namespaces.
+	if options.Namespace != "" && len(options.Namespaces) > 0 {
+		return nil, errors.New("Namespace and Namespaces can't be set at the same time")
+	}
+	if options.AllNamespaces && (options.Namespace != "" || len(options.Namespaces) > 0) {
+		return nil, errors.New("AllNamespaces can't be set together with Namespace or Namespaces")
+	}
+
+	// Rejects invalid label selectors before starting the discovery.
+	if options.LabelSelector != "" {
+		if _, err := labels.Parse(options.LabelSelector); err != nil {
+			return nil, errors.Wrapf(err, "invalid label selector %q", options.LabelSelector)
+		}
+	}
+
+	// Get the client for interacting with the source management cluster.
+	fromCluster, err := c.clusterClientFactory(cluster.Kubeconfig{Path: options.FromKubeconfig, Context: options.FromKubeconfigContext})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensures the custom resource definitions required by clusterctl are in place.
+	if err := fromCluster.ProviderInventory().EnsureCustomResourceDefinitions(); err != nil {
+		return nil, err
+	}
+
+	// If the options specifying the Namespace or the Namespaces are empty, try to detect it.
+	if options.Namespace == "" && len(options.Namespaces) == 0 {
+		currentNamespace, err := getContextNamespace(fromCluster.Proxy(), options.AllNamespaces, false)
+		if err != nil {
+			return nil, err
+		}
+		options.Namespace = currentNamespace
+	}
+
+	graph, err := fromCluster.ObjectMover().DiscoverGraph(cluster.MoveOptions{
+		Namespace:       options.Namespace,
+		Namespaces:      options.Namespaces,
+		ClusterName:     options.ClusterName,
+		LabelSelector:   options.LabelSelector,
+		ExcludeKinds:    options.ExcludeKinds,
+		StrictDiscovery: options.StrictDiscovery,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return (*MoveGraph)(graph), nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_writeKubeconfigFromSecret(t *testing.T) {
+	kubeconfigSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "foo-kubeconfig",
+		},
+		Data: map[string][]byte{
+			"value": []byte("kubeconfig"),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		secretRef string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "reads the kubeconfig from the value key",
+			secretRef: "ns1/foo-kubeconfig",
+			want:      "kubeconfig",
+			wantErr:   false,
+		},
+		{
+			name:      "fails if the Secret does not exist",
+			secretRef: "ns1/bar-kubeconfig",
+			wantErr:   true,
+		},
+		{
+			name:      "fails if the namespace is missing",
+			secretRef: "foo-kubeconfig",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			proxy := test.NewFakeProxy().WithObjs(kubeconfigSecret)
+
+			path, err := writeKubeconfigFromSecret(proxy, tt.secretRef)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			defer os.Remove(path)
+
+			got, err := ioutil.ReadFile(path)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(got)).To(Equal(tt.want))
+
+			info, err := os.Stat(path)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		})
+	}
+}
+
+// contextNamespaceProxy is a proxy returning a fixed namespace for the current context of the kubeconfig.
+type contextNamespaceProxy struct {
+	cluster.Proxy
+	namespace string
+}
+
+func (p contextNamespaceProxy) ContextNamespace() (string, error) {
+	return p.namespace, nil
+}
+
+func Test_getContextNamespace(t *testing.T) {
+	tests := []struct {
+		name             string
+		contextNamespace string
+		allNamespaces    bool
+		fromContext      bool
+		want             string
+		wantErr          bool
+	}{
+		{
+			name:             "returns the namespace of the context",
+			contextNamespace: "ns1",
+			want:             "ns1",
+		},
+		{
+			name:             "returns the default namespace if the context does not define a namespace",
+			contextNamespace: "",
+			want:             "default",
+		},
+		{
+			name:             "returns all the namespaces if requested",
+			contextNamespace: "ns1",
+			allNamespaces:    true,
+			want:             "",
+		},
+		{
+			name:             "returns the namespace of the context if requested",
+			contextNamespace: "ns1",
+			fromContext:      true,
+			want:             "ns1",
+		},
+		{
+			name:             "fails if the namespace of the context is requested, but the context does not define a namespace",
+			contextNamespace: "",
+			fromContext:      true,
+			wantErr:          true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			proxy := contextNamespaceProxy{Proxy: test.NewFakeProxy(), namespace: tt.contextNamespace}
+
+			got, err := getContextNamespace(proxy, tt.allNamespaces, tt.fromContext)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_clusterctlClient_MoveWithReport_validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		options MoveOptions
+		wantErr string
+	}{
+		{
+			name: "fails if ValidateOnly is set together with StateFile",
+			options: MoveOptions{
+				ToKubeconfig: "target-kubeconfig",
+				ValidateOnly: true,
+				StateFile:    "state.yaml",
+			},
+			wantErr: "ValidateOnly can't be set together with DryRun, StateFile",
+		},
+		{
+			name: "fails if Parallelism is negative",
+			options: MoveOptions{
+				ToKubeconfig: "target-kubeconfig",
+				Parallelism:  -1,
+			},
+			wantErr: "can't be negative",
+		},
+		{
+			name: "fails if Retries is negative",
+			options: MoveOptions{
+				ToKubeconfig: "target-kubeconfig",
+				Retries:      -1,
+			},
+			wantErr: "can't be negative",
+		},
+		{
+			name: "fails if RetryBackoff is negative",
+			options: MoveOptions{
+				ToKubeconfig: "target-kubeconfig",
+				RetryBackoff: -time.Second,
+			},
+			wantErr: "can't be negative",
+		},
+		{
+			name: "fails if DeleteTimeout is negative",
+			options: MoveOptions{
+				ToKubeconfig:  "target-kubeconfig",
+				DeleteTimeout: -time.Second,
+			},
+			wantErr: "can't be negative",
+		},
+		{
+			name: "fails if WaitForCompletionTimeout is negative",
+			options: MoveOptions{
+				ToKubeconfig:             "target-kubeconfig",
+				WaitForCompletion:        true,
+				WaitForCompletionTimeout: -time.Second,
+			},
+			wantErr: "can't be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			// Options are validated before connecting to any management cluster.
+			_, err := (&clusterctlClient{}).MoveWithReport(tt.options)
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
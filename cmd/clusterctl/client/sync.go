@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+func (c *clusterctlClient) Sync(options SyncOptions) (*MoveReport, error) {
+	// Objects are synced either from a single namespace or from a list of namespaces.
+	if options.Namespace != "" && len(options.Namespaces) > 0 {
+		return nil, errors.New("Namespace and Namespaces can't be set at the same time")
+	}
+	if options.AllNamespaces && (options.Namespace != "" || len(options.Namespaces) > 0) {
+		return nil, errors.New("AllNamespaces can't be set together with Namespace or Namespaces")
+	}
+	if options.FromContextNamespace && (options.Namespace != "" || len(options.Namespaces) > 0 || options.AllNamespaces) {
+		return nil, errors.New("FromContextNamespace can't be set together with Namespace, Namespaces or AllNamespaces")
+	}
+
+	if options.Diff && options.DryRun {
+		return nil, errors.New("Diff can't be set together with DryRun")
+	}
+	if options.VerifyObjects && options.Diff {
+		return nil, errors.New("VerifyObjects can't be set together with Diff")
+	}
+
+	// Zero means using the default, while negative values are rejected.
+	if options.Parallelism < 0 || options.Retries < 0 {
+		return nil, errors.New("Parallelism and Retries can't be negative")
+	}
+	if options.Timeout < 0 || options.TargetReadyTimeout < 0 || options.RetryBackoff < 0 {
+		return nil, errors.New("Timeout, TargetReadyTimeout and RetryBackoff can't be negative")
+	}
+
+	// Rejects invalid label selectors before starting the sync operation.
+	if options.LabelSelector != "" {
+		if _, err := labels.Parse(options.LabelSelector); err != nil {
+			return nil, errors.Wrapf(err, "invalid label selector %q", options.LabelSelector)
+		}
+	}
+
+	transformers, err := getObjectTransformers(options.Transformers, options.TransformFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the client for interacting with the source management cluster.
+	fromCluster, err := c.clusterClientFactory(cluster.Kubeconfig{Path: options.FromKubeconfig, Context: options.FromKubeconfigContext})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensures the custom resource definitions required by clusterctl are in place.
+	if err := fromCluster.ProviderInventory().EnsureCustomResourceDefinitions(); err != nil {
+		return nil, err
+	}
+
+	// If the options specifying the Namespace or the Namespaces are empty, try to detect it.
+	if options.Namespace == "" && len(options.Namespaces) == 0 {
+		currentNamespace, err := getContextNamespace(fromCluster.Proxy(), options.AllNamespaces, options.FromContextNamespace)
+		if err != nil {
+			return nil, err
+		}
+		options.Namespace = currentNamespace
+	}
+
+	// Get the client for interacting with the target management cluster.
+	// Nb. when running in dry-run mode the target management cluster is not required.
+	var toCluster cluster.Client
+	if !options.DryRun {
+		toCluster, err = c.clusterClientFactory(cluster.Kubeconfig{Path: options.ToKubeconfig, Context: options.ToKubeconfigContext})
+		if err != nil {
+			return nil, err
+		}
+
+		// Ensures the custom resource definitions required by clusterctl are in place
+		if err := toCluster.ProviderInventory().EnsureCustomResourceDefinitions(); err != nil {
+			return nil, err
+		}
+	}
+
+	return toMoveReport(fromCluster.ObjectMover().Sync(toCluster, cluster.MoveOptions{
+		Namespace:          options.Namespace,
+		Namespaces:         options.Namespaces,
+		ClusterName:        options.ClusterName,
+		LabelSelector:      options.LabelSelector,
+		ExcludeKinds:       options.ExcludeKinds,
+		Since:              options.Since,
+		StrictDiscovery:    options.StrictDiscovery,
+		Parallelism:        options.Parallelism,
+		Timeout:            options.Timeout,
+		TargetReadyTimeout: options.TargetReadyTimeout,
+		Retries:            options.Retries,
+		RetryBackoff:       options.RetryBackoff,
+		ContinueOnError:    options.ContinueOnError,
+		Transformers:       transformers,
+		Diff:               options.Diff,
+		VerifyObjects:      options.VerifyObjects,
+		DryRun:             options.DryRun,
+	}))
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_clusterctlClient_Sync_validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		options SyncOptions
+		wantErr string
+	}{
+		{
+			name: "fails if Parallelism is negative",
+			options: SyncOptions{
+				ToKubeconfig: "target-kubeconfig",
+				Parallelism:  -1,
+			},
+			wantErr: "can't be negative",
+		},
+		{
+			name: "fails if Retries is negative",
+			options: SyncOptions{
+				ToKubeconfig: "target-kubeconfig",
+				Retries:      -1,
+			},
+			wantErr: "can't be negative",
+		},
+		{
+			name: "fails if RetryBackoff is negative",
+			options: SyncOptions{
+				ToKubeconfig: "target-kubeconfig",
+				RetryBackoff: -time.Second,
+			},
+			wantErr: "can't be negative",
+		},
+		{
+			name: "fails if TargetReadyTimeout is negative",
+			options: SyncOptions{
+				ToKubeconfig:       "target-kubeconfig",
+				TargetReadyTimeout: -time.Second,
+			},
+			wantErr: "can't be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			// Options are validated before connecting to any management cluster.
+			_, err := (&clusterctlClient{}).Sync(tt.options)
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
@@ -172,7 +172,7 @@ func Test_clusterctlClient_ApplyUpgrade(t *testing.T) {
 			}
 			g.Expect(err).NotTo(HaveOccurred())
 
-			proxy := tt.fields.client.clusters["kubeconfig"].Proxy()
+			proxy := tt.fields.client.clusters[cluster.Kubeconfig{Path: "kubeconfig"}].Proxy()
 			gotProviders := &clusterctlv1.ProviderList{}
 
 			c, err := proxy.NewClient()
@@ -223,7 +223,7 @@ func fakeClientFoUpgrade() *fakeClient {
 			},
 		})
 
-	cluster1 := newFakeCluster("kubeconfig", config1).
+	cluster1 := newFakeCluster(cluster.Kubeconfig{Path: "kubeconfig"}, config1).
 		WithRepository(repository1).
 		WithRepository(repository2).
 		WithProviderInventory(core.Name(), core.Type(), "v1.0.0", "cluster-api-system", "").
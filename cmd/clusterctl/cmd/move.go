@@ -17,15 +17,73 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
 )
 
 type moveOptions struct {
-	fromKubeconfig string
-	namespace      string
-	toKubeconfig   string
+	fromKubeconfig  string
+	fromContext     string
+	namespace       string
+	namespaces      []string
+	allNamespaces   bool
+	fromContextNs   bool
+	clusterName     string
+	labelSelector   string
+	sharedOnly      bool
+	rootKind        string
+	excludeKinds    []string
+	strictDiscovery bool
+	externalInfra   bool
+	parallelism     int
+	stateFile       string
+	resume          bool
+	timeout         time.Duration
+	toNamespace     string
+	rewriteRefs     map[string]string
+	rewriteFinals   map[string]string
+	renameClusters  map[string]string
+	validateOnly    bool
+	listObjects     bool
+	readyTimeout    time.Duration
+	deleteTimeout   time.Duration
+	graphOutput     string
+	retries         int
+	retryBackoff    time.Duration
+	toKubeconfig    string
+	toContext       string
+	toSecret        string
+	toDirectory     string
+	fromDirectory   string
+	toArchive       string
+	fromArchive     string
+	skipVerify      bool
+	skipExisting    bool
+	verifyObjects   bool
+	provenance      bool
+	movedFrom       string
+	movedAt         string
+	pauseOnly       bool
+	continueOnError bool
+	sync            bool
+	diff            bool
+	since           string
+	transformFile   string
+	copyOnly        bool
+	allowUnsafeCopy bool
+	preservePaused  bool
+	waitCompletion  bool
+	waitTimeout     time.Duration
+	unpauseOnly     bool
+	dryRun          bool
+	quiet           bool
+	output          string
 }
 
 var mo = &moveOptions{}
@@ -40,27 +98,245 @@ var moveCmd = &cobra.Command{
 
 	Example: Examples(`
 		Move Cluster API objects and all dependencies between management clusters.
-		clusterctl move --to-kubeconfig=target-kubeconfig.yaml`),
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml
+
+		# Move Cluster API objects and all dependencies from the team-a namespace to the team-a-prod namespace in the destination management cluster.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --namespace=team-a --to-namespace=team-a-prod
+
+		# Move Cluster API objects and all dependencies from all the namespaces.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --all-namespaces
+
+		# Move Cluster API objects and all dependencies between two management clusters defined as contexts in the same kubeconfig file.
+		clusterctl move --kubeconfig-context=mgmt-old --to-kubeconfig=$HOME/.kube/config --to-kubeconfig-context=mgmt-new
+
+		# Move Cluster API objects and all dependencies from a bootstrap cluster to the workload cluster becoming a management
+		# cluster, reading its kubeconfig from the Secret Cluster API stores it in.
+		clusterctl move --to-kubeconfig-secret=default/my-cluster-kubeconfig
+
+		# Move only the Cluster named "my-cluster" and all its dependencies between management clusters.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --cluster-name=my-cluster
+
+		# Move only the Clusters labeled with environment=staging and all their dependencies between management clusters.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml -l environment=staging
+
+		# Move the objects shared by many Clusters, e.g. credential Secrets labeled with clusterctl.cluster.x-k8s.io/move-shared, before moving the Clusters.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --shared-only
+
+		# Move only the MachineDeployments of the paused Cluster "my-cluster" and all their dependencies, leaving the Cluster and
+		# the objects the MachineDeployments depend on in the source management cluster.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --cluster-name=my-cluster --root-kind=cluster.x-k8s.io/MachineDeployment
+
+		# Move Cluster API objects and all dependencies between management clusters, leaving the infrastructure objects managed by
+		# an external system, i.e. with the cluster.x-k8s.io/managed-by annotation, in the source management cluster.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --infrastructure-managed-externally
+
+		# Move Cluster API objects and all dependencies between management clusters, leaving the IPPool objects in the source management cluster.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --exclude=ipam.cluster.x-k8s.io/IPPool
+
+		# Move Cluster API objects recording the progress to a state file; if the move is interrupted, it can be resumed by
+		# re-running the same command with the --resume flag.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --state-file=move-state.yaml
+
+		# Save Cluster API objects and all dependencies to a directory, e.g. as a backup.
+		clusterctl move --to-directory=/tmp/backup-directory
+
+		# Restore Cluster API objects and all dependencies previously saved to a directory.
+		clusterctl move --from-directory=/tmp/backup-directory --to-kubeconfig=target-kubeconfig.yaml
+
+		# Save Cluster API objects and all dependencies to a single compressed archive, and restore them.
+		clusterctl move --to-archive=backup.tar.gz
+		clusterctl move --from-archive=backup.tar.gz --to-kubeconfig=target-kubeconfig.yaml
+
+		# Move Cluster API objects and all dependencies between management clusters, keeping paused the Clusters that were
+		# already paused in the source management cluster.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --preserve-paused
+
+		# Move Cluster API objects and all dependencies between management clusters, printing a machine-readable summary.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml -o json
+
+		# Check that Cluster API objects can be moved to the destination management cluster, without moving them.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --validate-only
+
+		# Resume the reconciliation of the Clusters in the source management cluster after a failed move.
+		clusterctl move --unpause-only --namespace=team-a
+
+		# Clone Cluster API objects and all dependencies to a lab management cluster, leaving them in the source management cluster
+		# and using the rules in transform.yaml for changing the credentials of the cloned objects.
+		clusterctl move --to-kubeconfig=lab-kubeconfig.yaml --copy --transform=transform.yaml
+
+		# Clone the Cluster "my-cluster" and all its dependencies to a lab management cluster, renaming it to "my-cluster-lab".
+		clusterctl move --to-kubeconfig=lab-kubeconfig.yaml --copy --transform=transform.yaml --cluster-name=my-cluster --rename-cluster=my-cluster=my-cluster-lab
+
+		# Copy Cluster API objects and all dependencies to a warm-standby management cluster, without pausing or deleting them in
+		# the source management cluster; re-running the same command updates the objects changed since the previous run.
+		clusterctl move --to-kubeconfig=standby-kubeconfig.yaml --sync
+
+		# Sync to a warm-standby management cluster only the objects modified after the previous sync, together with the objects they depend on.
+		clusterctl move --to-kubeconfig=standby-kubeconfig.yaml --sync --since=2020-06-01T10:00:00Z
+
+		# Print the list of Cluster API objects that would be moved, without moving them.
+		clusterctl move --dry-run
+
+		# Print the Cluster API objects that would be moved, one per line, without checking them or computing the move sequence.
+		clusterctl move --list-objects --cluster-name=my-cluster | grep AWSMachine
+
+		# Write the Cluster API objects that would be moved and their dependencies to a Graphviz DOT file, without moving them.
+		clusterctl move --dry-run --graph-output=move.dot`),
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runMove()
+		return runMove(cmd)
 	},
 }
 
 func init() {
 	moveCmd.Flags().StringVar(&mo.fromKubeconfig, "kubeconfig", "",
 		"Path to the kubeconfig file for the source management cluster. If unspecified, default discovery rules apply.")
+	moveCmd.Flags().StringVar(&mo.fromContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file for the source management cluster. If empty, current context will be used.")
 	moveCmd.Flags().StringVar(&mo.toKubeconfig, "to-kubeconfig", "",
 		"Path to the kubeconfig file to use for the destination management cluster.")
+	moveCmd.Flags().StringVar(&mo.toContext, "to-kubeconfig-context", "",
+		"Context to be used within the kubeconfig file for the destination management cluster. If empty, current context will be used.")
+	moveCmd.Flags().StringVar(&mo.toSecret, "to-kubeconfig-secret", "",
+		"Secret in the source management cluster, in the namespace/name format, storing the kubeconfig for the destination management cluster under the value key, e.g. the kubeconfig Secret of a workload cluster becoming a management cluster. Can't be used together with --to-kubeconfig.")
+	moveCmd.Flags().StringVar(&mo.toDirectory, "to-directory", "",
+		"Path to a directory where Cluster API objects should be saved, one YAML file for each object, instead of moving them to a destination management cluster.")
+	moveCmd.Flags().StringVar(&mo.fromDirectory, "from-directory", "",
+		"Path to a directory where Cluster API objects were previously saved using --to-directory, to be restored to the destination management cluster instead of moving them from a source management cluster.")
+	moveCmd.Flags().StringVar(&mo.toArchive, "to-archive", "",
+		"Path to a gzip-compressed tarball (e.g. backup.tar.gz) where Cluster API objects should be saved, streaming them one at a time, instead of saving them to a directory.")
+	moveCmd.Flags().StringVar(&mo.fromArchive, "from-archive", "",
+		"Path to a gzip-compressed tarball where Cluster API objects were previously saved using --to-archive, to be restored to the destination management cluster instead of moving them from a source management cluster.")
+	moveCmd.Flags().BoolVar(&mo.skipVerify, "skip-verify", false,
+		"Restore the objects saved in the directory defined by --from-directory without verifying their checksum against the manifest written by --to-directory.")
 	moveCmd.Flags().StringVarP(&mo.namespace, "namespace", "n", "",
-		"The namespace where the workload cluster is hosted. If unspecified, the current context's namespace is used.")
+		"The namespace where the workload cluster is hosted. If unspecified, the current context's namespace is used; if the current context does not define a namespace, the default namespace is used, unless --from-context-namespace is set.")
+	moveCmd.Flags().StringSliceVar(&mo.namespaces, "namespaces", nil,
+		"Comma-separated list of the namespaces where the workload clusters are hosted, for moving related clusters hosted in many namespaces in a single operation. Can't be used together with --namespace or --to-namespace.")
+	moveCmd.Flags().BoolVarP(&mo.allNamespaces, "all-namespaces", "A", false,
+		"Move the objects from all the namespaces. Can't be used together with --namespace, --namespaces or --to-namespace.")
+	moveCmd.Flags().BoolVar(&mo.fromContextNs, "from-context-namespace", false,
+		"Move the objects from the current context's namespace, failing if the current context does not define a namespace instead of using the default namespace. Can't be used together with --namespace, --namespaces or --all-namespaces.")
+	moveCmd.Flags().StringVar(&mo.toNamespace, "to-namespace", "",
+		"The namespace in the destination management cluster where the objects should be moved to. The namespace must already exist. If unspecified, the namespace of the source management cluster is used.")
+	moveCmd.Flags().StringToStringVar(&mo.rewriteRefs, "rewrite-ref", nil,
+		"Remap the references to objects in a namespace other than the one being moved, in the source=destination format (e.g. capa-system=capa-prod), when using --to-namespace. Can be repeated.")
+	moveCmd.Flags().StringToStringVar(&mo.rewriteFinals, "rewrite-finalizer", nil,
+		"Rewrite a finalizer of the moved objects in the source=destination format, e.g. for finalizers referencing a controller by an instance-specific name; an empty destination (e.g. example.com/capa-east=) removes the finalizer. Finalizers not listed are left untouched. Can be repeated.")
+	moveCmd.Flags().StringToStringVar(&mo.renameClusters, "rename-cluster", nil,
+		"Rename a Cluster in the destination management cluster, in the source=destination format (e.g. my-cluster=my-cluster-lab), together with the Secrets named after it and all the references to it. Can be repeated.")
+	moveCmd.Flags().StringVar(&mo.clusterName, "cluster-name", "",
+		"The name of the Cluster to be moved together with all its dependencies. If unspecified, all the Clusters in the namespace are moved.")
+	moveCmd.Flags().StringVarP(&mo.labelSelector, "label-selector", "l", "",
+		"Label selector (e.g. environment=staging) for the Clusters to be moved together with all their dependencies. If unspecified, all the Clusters in the namespace are moved.")
+	moveCmd.Flags().BoolVar(&mo.sharedOnly, "shared-only", false,
+		"Move only the cluster-scoped objects and the objects labeled with clusterctl.cluster.x-k8s.io/move-shared, together with all their dependencies, without moving any Cluster.")
+	moveCmd.Flags().StringVar(&mo.rootKind, "root-kind", "",
+		"Kind of the objects, in the group/kind format (e.g. cluster.x-k8s.io/MachineDeployment), to be moved together with all their dependencies instead of the Clusters, for specialized recovery scenarios. "+
+			"WARNING: the Clusters and all the other objects the moved objects depend on, e.g. infrastructure templates, are left in the source management cluster, so the moved objects are going to reference objects missing in the destination management cluster. "+
+			"The Clusters owning the moved objects must be paused beforehand, e.g. using --pause-only, and are left paused.")
+	moveCmd.Flags().StringArrayVar(&mo.excludeKinds, "exclude", nil,
+		"Kind of the objects, in the group/kind format (e.g. infrastructure.cluster.x-k8s.io/AWSMachine), that should be left in the source management cluster. Can be repeated.")
+	moveCmd.Flags().BoolVar(&mo.strictDiscovery, "strict-discovery", false,
+		"Fail if a kind defined by the CRDs installed by clusterctl is not served by the source management cluster, e.g. because a provider was partially uninstalled. If unspecified, the kind is skipped and a warning is printed.")
+	moveCmd.Flags().BoolVar(&mo.externalInfra, "infrastructure-managed-externally", false,
+		"Leave in the source management cluster the infrastructure objects managed by an external system, i.e. with the cluster.x-k8s.io/managed-by annotation, while moving the objects referencing them, e.g. the Clusters. The externally managed objects must already exist in the destination management cluster.")
+	moveCmd.Flags().IntVarP(&mo.parallelism, "parallelism", "P", 1,
+		"The maximum number of independent objects to be created or deleted concurrently.")
+	moveCmd.Flags().StringVar(&mo.stateFile, "state-file", "",
+		"Path to a file where the progress of the move is recorded, so an interrupted move can be resumed using --resume. The file is deleted when the move completes.")
+	moveCmd.Flags().BoolVar(&mo.resume, "resume", false,
+		"Resume an interrupted move using the progress recorded in the file defined by --state-file.")
+	moveCmd.Flags().DurationVar(&mo.timeout, "timeout", 0,
+		"The maximum duration of the move, e.g. 10m; once the timeout expires the move is aborted without starting any further change. If unspecified, no timeout applies.")
+	moveCmd.Flags().BoolVar(&mo.dryRun, "dry-run", false,
+		"Print the objects that would be moved, in the order they would be processed, without making any change to the source or the destination management cluster.")
+	moveCmd.Flags().BoolVar(&mo.validateOnly, "validate-only", false,
+		"Check that the providers and the CRDs installed in the source management cluster are installed in the destination management cluster too, and that all the objects can be moved, without moving them.")
+	moveCmd.Flags().BoolVar(&mo.listObjects, "list-objects", false,
+		"Print the objects that would be moved, one per line, without checking them, without computing the order they would be processed and without accessing the destination management cluster.")
+	moveCmd.Flags().DurationVar(&mo.readyTimeout, "target-ready-timeout", 5*time.Minute,
+		"How long to wait for the providers in the destination management cluster to be available before starting the move.")
+	moveCmd.Flags().DurationVar(&mo.deleteTimeout, "delete-timeout", 5*time.Minute,
+		"How long to wait for the objects deleted from the source management cluster to disappear; if some objects still exist after this time, e.g. blocked by finalizers, the move fails listing them.")
+	moveCmd.Flags().StringVar(&mo.graphOutput, "graph-output", "",
+		"Path to a file where the objects to be moved and their dependencies are written in the Graphviz DOT format, e.g. for reviewing the move plan in combination with --dry-run.")
+	moveCmd.Flags().IntVar(&mo.retries, "retries", 9,
+		"How many times creating or deleting an object is retried after a transient error, e.g. a server timeout or a network error. Use 0 for not retrying.")
+	moveCmd.Flags().DurationVar(&mo.retryBackoff, "retry-backoff", 500*time.Millisecond,
+		"The initial delay before retrying after a transient error; the delay grows exponentially at each retry.")
+	moveCmd.Flags().BoolVar(&mo.skipExisting, "skip-existing", false,
+		"Leave untouched the objects already existing in the destination management cluster, e.g. after a partial manual migration, instead of overwriting them.")
+	moveCmd.Flags().BoolVar(&mo.verifyObjects, "verify", false,
+		"Read back each object created in the destination management cluster and report the objects that differ from what was created, e.g. because of defaulting or mutating webhooks; server-managed fields and the status are ignored. Unlike --skip-verify, this does not relate to --from-directory.")
+	moveCmd.Flags().BoolVar(&mo.provenance, "annotate-provenance", false,
+		"Annotate the objects created in the destination management cluster with the kubeconfig context of the source management cluster, or the directory defined by --from-directory, and with the time of the move.")
+	moveCmd.Flags().StringVar(&mo.movedFrom, "moved-from-annotation", clusterctlv1.ClusterctlMovedFromAnnotation,
+		"The key of the annotation recording where the objects were moved from, when using --annotate-provenance.")
+	moveCmd.Flags().StringVar(&mo.movedAt, "moved-at-annotation", clusterctlv1.ClusterctlMovedAtAnnotation,
+		"The key of the annotation recording when the objects were moved, when using --annotate-provenance.")
+	moveCmd.Flags().BoolVar(&mo.continueOnError, "continue-on-error", false,
+		"Keep moving the remaining objects when moving an object fails, and report all the errors at the end. The objects of the Clusters with failed objects are kept in the source management cluster, with the Clusters paused.")
+	moveCmd.Flags().BoolVar(&mo.waitCompletion, "wait-for-move-completion", false,
+		"Once all the objects are moved, wait for the Clusters to be ready and for the Machines to have a node in the destination management cluster, and fail listing the objects not provisioned within --wait-for-move-completion-timeout.")
+	moveCmd.Flags().DurationVar(&mo.waitTimeout, "wait-for-move-completion-timeout", 10*time.Minute,
+		"How long to wait for the Clusters and the Machines to be provisioned in the destination management cluster, when using --wait-for-move-completion.")
+	moveCmd.Flags().StringVar(&mo.transformFile, "transform", "",
+		"Path to a file with rules setting fields on the objects before creating them in the destination management cluster, e.g. for changing the name of an identity Secret or a region label specific to an environment.")
+	moveCmd.Flags().BoolVar(&mo.copyOnly, "copy", false,
+		"Create the objects in the destination management cluster without pausing and deleting them in the source management cluster, e.g. for cloning Clusters to a lab management cluster. Requires --transform, e.g. for changing the credentials of the copied objects, or --i-know-this-is-dangerous.")
+	moveCmd.Flags().BoolVar(&mo.allowUnsafeCopy, "i-know-this-is-dangerous", false,
+		"Acknowledge that, when using --copy without --transform, both management clusters are going to reconcile the same infrastructure.")
+	moveCmd.Flags().BoolVar(&mo.sync, "sync", false,
+		"Create or update the objects in the destination management cluster without pausing or deleting them in the source management cluster, e.g. for keeping a warm-standby management cluster. The Clusters are kept paused in the destination management cluster.")
+	moveCmd.Flags().BoolVar(&mo.diff, "diff", false,
+		"Print, for each object, whether --sync would create, update or leave it unchanged in the destination management cluster, together with the patch of the updated objects, without making any change.")
+	moveCmd.Flags().StringVar(&mo.since, "since", "",
+		"Sync only the objects modified after the given time, in the RFC3339 format, together with the objects they depend on, e.g. the time of the previous --sync. Objects without managedFields are always synced.")
+	moveCmd.Flags().BoolVar(&mo.preservePaused, "preserve-paused", false,
+		"Keep paused, at the end of the move, the Clusters that were already paused before the move, instead of resuming the reconciliation of all the moved Clusters.")
+	moveCmd.Flags().BoolVar(&mo.pauseOnly, "pause-only", false,
+		"Pause the reconciliation of the Clusters in the source management cluster, without moving any object.")
+	moveCmd.Flags().BoolVar(&mo.unpauseOnly, "unpause-only", false,
+		"Resume the reconciliation of the Clusters in the source management cluster, without moving any object, e.g. for recovering from a failed move.")
+	moveCmd.Flags().BoolVarP(&mo.quiet, "quiet", "q", false,
+		"Do not print the progress of the move and the final summary.")
+	moveCmd.Flags().StringVarP(&mo.output, "output", "o", "",
+		"Output format for the final summary; the only available option is 'json'. When set, the progress of the move is not printed.")
 
 	RootCmd.AddCommand(moveCmd)
 }
 
-func runMove() error {
-	if mo.toKubeconfig == "" {
-		return errors.New("please specify a target cluster using the --to-kubeconfig flag")
+func runMove(cmd *cobra.Command) error {
+	// Nb. The combinations of options are validated by the client library, so library users get the same errors; only the flags
+	// not supported by the operation selected by --sync, which would be silently ignored otherwise, are rejected here.
+	if err := checkSyncFlags(cmd); err != nil {
+		return err
+	}
+
+	// A target must be defined explicitly, so the objects are not moved to the cluster of the default kubeconfig by accident.
+	hasTargetCluster := mo.toKubeconfig != "" || mo.toContext != "" || mo.toSecret != ""
+	toBackup := mo.toDirectory != "" || mo.toArchive != ""
+	if !hasTargetCluster && !toBackup && !mo.dryRun && !mo.pauseOnly && !mo.unpauseOnly && !mo.listObjects {
+		return errors.New("please specify a target cluster using the --to-kubeconfig flag, or a target directory or archive using the --to-directory or --to-archive flag")
+	}
+
+	var since time.Time
+	if mo.since != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, mo.since); err != nil {
+			return errors.Errorf("invalid --since time %q: the RFC3339 format is required, e.g. 2020-06-01T10:00:00Z", mo.since)
+		}
+	}
+
+	if mo.output != "" && mo.output != "json" {
+		return errors.Errorf("invalid output format: %s", mo.output)
+	}
+
+	// Suppress the progress of the move, so only errors or the machine-readable summary are printed.
+	// Nb. The progress is suppressed when listing the objects too, so the list can be processed by other tools, e.g. grep.
+	if mo.quiet || mo.output != "" || mo.listObjects {
+		*verbosity = -1
 	}
 
 	c, err := client.New(cfgFile)
@@ -68,12 +344,141 @@ func runMove() error {
 		return err
 	}
 
-	if err := c.Move(client.MoveOptions{
-		FromKubeconfig: mo.fromKubeconfig,
-		ToKubeconfig:   mo.toKubeconfig,
-		Namespace:      mo.namespace,
-	}); err != nil {
-		return err
+	// Objects are copied without pausing or deleting them in the source management cluster.
+	if mo.sync {
+		return printMoveSummary(c.Sync(client.SyncOptions{
+			FromKubeconfig:        mo.fromKubeconfig,
+			FromKubeconfigContext: mo.fromContext,
+			ToKubeconfig:          mo.toKubeconfig,
+			ToKubeconfigContext:   mo.toContext,
+			Namespace:             mo.namespace,
+			Namespaces:            mo.namespaces,
+			AllNamespaces:         mo.allNamespaces,
+			FromContextNamespace:  mo.fromContextNs,
+			ClusterName:           mo.clusterName,
+			LabelSelector:         mo.labelSelector,
+			ExcludeKinds:          mo.excludeKinds,
+			StrictDiscovery:       mo.strictDiscovery,
+			Since:                 since,
+			Parallelism:           mo.parallelism,
+			Timeout:               mo.timeout,
+			TargetReadyTimeout:    mo.readyTimeout,
+			Retries:               mo.retries,
+			RetryBackoff:          mo.retryBackoff,
+			ContinueOnError:       mo.continueOnError,
+			TransformFile:         mo.transformFile,
+			Diff:                  mo.diff,
+			VerifyObjects:         mo.verifyObjects,
+			DryRun:                mo.dryRun,
+		}))
+	}
+
+	return printMoveSummary(c.MoveWithReport(client.MoveOptions{
+		FromKubeconfig:           mo.fromKubeconfig,
+		FromKubeconfigContext:    mo.fromContext,
+		FromDirectory:            mo.fromDirectory,
+		FromArchive:              mo.fromArchive,
+		SkipVerify:               mo.skipVerify,
+		SkipExisting:             mo.skipExisting,
+		VerifyObjects:            mo.verifyObjects,
+		AnnotateProvenance:       mo.provenance,
+		MovedFromAnnotation:      mo.movedFrom,
+		MovedAtAnnotation:        mo.movedAt,
+		ToKubeconfig:             mo.toKubeconfig,
+		ToKubeconfigContext:      mo.toContext,
+		ToKubeconfigSecret:       mo.toSecret,
+		ToDirectory:              mo.toDirectory,
+		ToArchive:                mo.toArchive,
+		Namespace:                mo.namespace,
+		Namespaces:               mo.namespaces,
+		AllNamespaces:            mo.allNamespaces,
+		FromContextNamespace:     mo.fromContextNs,
+		ClusterName:              mo.clusterName,
+		LabelSelector:            mo.labelSelector,
+		SharedOnly:               mo.sharedOnly,
+		RootKind:                 mo.rootKind,
+		ExcludeKinds:             mo.excludeKinds,
+		StrictDiscovery:          mo.strictDiscovery,
+		ExternalInfrastructure:   mo.externalInfra,
+		Parallelism:              mo.parallelism,
+		StateFile:                mo.stateFile,
+		Resume:                   mo.resume,
+		Timeout:                  mo.timeout,
+		ToNamespace:              mo.toNamespace,
+		RewriteRefs:              mo.rewriteRefs,
+		RewriteFinalizers:        mo.rewriteFinals,
+		RenameClusters:           mo.renameClusters,
+		ValidateOnly:             mo.validateOnly,
+		ListObjects:              mo.listObjects,
+		TargetReadyTimeout:       mo.readyTimeout,
+		DeleteTimeout:            mo.deleteTimeout,
+		GraphOutput:              mo.graphOutput,
+		Retries:                  mo.retries,
+		RetryBackoff:             mo.retryBackoff,
+		PauseOnly:                mo.pauseOnly,
+		UnpauseOnly:              mo.unpauseOnly,
+		ContinueOnError:          mo.continueOnError,
+		CopyOnly:                 mo.copyOnly,
+		AllowUnsafeCopy:          mo.allowUnsafeCopy,
+		PreservePaused:           mo.preservePaused,
+		TransformFile:            mo.transformFile,
+		WaitForCompletion:        mo.waitCompletion,
+		WaitForCompletionTimeout: mo.waitTimeout,
+		DryRun:                   mo.dryRun,
+	}))
+}
+
+// moveOnlyFlags lists the flags not supported by --sync.
+var moveOnlyFlags = []string{
+	"to-kubeconfig-secret", "to-directory", "to-archive", "from-directory", "from-archive", "skip-verify", "to-namespace",
+	"rewrite-ref", "rewrite-finalizer", "rename-cluster", "shared-only", "root-kind", "infrastructure-managed-externally",
+	"state-file", "resume", "validate-only", "list-objects", "delete-timeout", "graph-output", "skip-existing",
+	"annotate-provenance", "moved-from-annotation", "moved-at-annotation", "wait-for-move-completion",
+	"wait-for-move-completion-timeout", "copy", "i-know-this-is-dangerous", "preserve-paused", "pause-only", "unpause-only",
+}
+
+// syncOnlyFlags lists the flags that can be used only together with --sync.
+var syncOnlyFlags = []string{"diff", "since"}
+
+// checkSyncFlags checks that the flags not supported by the operation selected by --sync, i.e. either sync or move, are not set.
+func checkSyncFlags(cmd *cobra.Command) error {
+	if mo.sync {
+		for _, name := range moveOnlyFlags {
+			if cmd.Flags().Changed(name) {
+				return errors.Errorf("the --%s flag can't be used together with --sync", name)
+			}
+		}
+		return nil
+	}
+	for _, name := range syncOnlyFlags {
+		if cmd.Flags().Changed(name) {
+			return errors.Errorf("the --%s flag can be used only together with --sync", name)
+		}
 	}
 	return nil
 }
+
+// printMoveSummary prints the summary of a move, if required, and returns the error of the move, if any.
+func printMoveSummary(summary *client.MoveReport, err error) error {
+	// Nb. The summary of a failed move is printed too, so automation can find out which objects failed.
+	if mo.output == "json" && summary != nil {
+		s, jsonErr := json.MarshalIndent(summary, "", "  ")
+		if jsonErr != nil {
+			return jsonErr
+		}
+		fmt.Println(string(s))
+		return err
+	}
+
+	// Prints the objects that would be moved, one per line, if required.
+	if mo.listObjects && summary != nil {
+		for _, obj := range summary.Discovered {
+			name := obj.Name
+			if obj.Namespace != "" {
+				name = obj.Namespace + "/" + name
+			}
+			fmt.Printf("%s %s %s\n", obj.APIVersion, obj.Kind, name)
+		}
+	}
+	return err
+}
@@ -28,6 +28,9 @@ import (
 
 var cfgFile string
 
+// verbosity is the log level verbosity set by the -v flag.
+var verbosity *int
+
 var RootCmd = &cobra.Command{
 	Use:          "clusterctl",
 	SilenceUsage: true,
@@ -48,7 +51,7 @@ func Execute() {
 func init() {
 	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 
-	verbosity := flag.CommandLine.Int("v", 0, "Set the log level verbosity.")
+	verbosity = flag.CommandLine.Int("v", 0, "Set the log level verbosity.")
 	logf.SetLogger(logf.NewLogger(logf.WithThreshold(verbosity)))
 
 	RootCmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
@@ -937,7 +937,7 @@ func FakeCustomResourceDefinition(group string, kind string, versions ...string)
 
 	for i, version := range versions {
 		// set the first version as a storage version
-		versionObj := apiextensionslv1.CustomResourceDefinitionVersion{Name: version}
+		versionObj := apiextensionslv1.CustomResourceDefinitionVersion{Name: version, Served: true}
 		if i == 0 {
 			versionObj.Storage = true
 		}
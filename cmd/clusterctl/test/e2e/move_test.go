@@ -89,6 +89,7 @@ var _ = Describe("clusterctl move", func() {
 		err = c.Move(clusterctlclient.MoveOptions{
 			FromKubeconfig: fromMgmtInfo.mgmtCluster.KubeconfigPath,
 			ToKubeconfig:   toMgmtInfo.mgmtCluster.KubeconfigPath,
+			Namespace:      "default",
 		})
 		Expect(err).ToNot(HaveOccurred())
 